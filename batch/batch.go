@@ -0,0 +1,207 @@
+// Package batch extracts business data for many Google Maps URLs in one run,
+// sharing a single browser session and writing the results out as vCards.
+package batch
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"gmaps2vcard/encoder"
+	"gmaps2vcard/scraper"
+)
+
+// Status describes the outcome of extracting a single URL.
+type Status string
+
+const (
+	StatusOK     Status = "ok"
+	StatusFailed Status = "failed"
+)
+
+// Result holds the outcome of extracting one URL in a batch run.
+type Result struct {
+	URL      string
+	Business *scraper.BusinessData
+	Status   Status
+	Error    error
+}
+
+// Config controls how a batch run is executed.
+type Config struct {
+	Concurrency   int
+	ScraperConfig *scraper.Config
+}
+
+// DefaultConfig returns sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Concurrency:   3,
+		ScraperConfig: scraper.DefaultConfig(),
+	}
+}
+
+// ReadURLs reads one Google Maps URL per line from r. Blank lines and lines
+// starting with '#' are skipped; for CSV input, only the first column of
+// each line is used.
+func ReadURLs(r io.Reader) ([]string, error) {
+	var urls []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if idx := strings.Index(line, ","); idx != -1 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		urls = append(urls, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read URLs: %w", err)
+	}
+
+	return urls, nil
+}
+
+// Run extracts business data for every URL using a scraper.Pool sized to
+// cfg.Concurrency, so Chrome starts once for the whole batch instead of once
+// per URL. A failure on one URL does not abort the run; it is recorded in
+// that URL's Result.
+func Run(urls []string, cfg *Config) []Result {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	results := make([]Result, len(urls))
+
+	pool, err := scraper.NewPool(cfg.Concurrency, cfg.ScraperConfig)
+	if err != nil {
+		for i, u := range urls {
+			results[i] = Result{URL: u, Status: StatusFailed, Error: err}
+		}
+		return results
+	}
+	defer pool.Close()
+
+	// Reject non-Google-Maps URLs before they ever reach the pool - scraper.
+	// Extract would catch them too, but checking here records the failure
+	// without spending a browser tab on a URL that can never succeed.
+	resultChs := make([]<-chan scraper.Result, len(urls))
+	for i, u := range urls {
+		if err := scraper.ValidateGoogleMapsURL(u); err != nil {
+			results[i] = Result{URL: u, Status: StatusFailed, Error: err}
+			continue
+		}
+		resultChs[i] = pool.Submit(u)
+	}
+
+	for i, ch := range resultChs {
+		if ch == nil {
+			continue // already recorded as failed above
+		}
+		r := <-ch
+		if r.Err != nil {
+			results[i] = Result{URL: r.URL, Status: StatusFailed, Error: r.Err}
+			continue
+		}
+		results[i] = Result{URL: r.URL, Business: r.Business, Status: StatusOK}
+	}
+
+	return results
+}
+
+// PrintSummary writes a table of URL, name, status, and error to w.
+func PrintSummary(results []Result, w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "URL\tNAME\tSTATUS\tERROR")
+	for _, r := range results {
+		name := ""
+		if r.Business != nil {
+			name = r.Business.Name
+		}
+		errStr := ""
+		if r.Error != nil {
+			errStr = r.Error.Error()
+		}
+		fmt.Fprintf(tw, "%.60s\t%s\t%s\t%s\n", r.URL, name, r.Status, errStr)
+	}
+	tw.Flush()
+}
+
+// Write renders every successful result with enc and writes it to disk. If
+// combined is true, every encoding is concatenated into a single file at
+// outPath; otherwise one file per business, named after it with ext, is
+// written into the outPath directory.
+func Write(results []Result, enc encoder.Encoder, combined bool, outPath, ext string) error {
+	if combined {
+		var buf strings.Builder
+		// CSVEncoder.Encode writes a header before every row, which would
+		// repeat once per business in a combined file; write the header once
+		// via EncodeHeader/EncodeRow instead so the result is valid CSV.
+		csvEnc, isCSV := enc.(encoder.CSVEncoder)
+		headerWritten := false
+		for _, r := range results {
+			if r.Status != StatusOK {
+				continue
+			}
+			if isCSV {
+				if !headerWritten {
+					if err := csvEnc.EncodeHeader(&buf); err != nil {
+						return fmt.Errorf("failed to encode %s: %w", r.Business.Name, err)
+					}
+					headerWritten = true
+				}
+				if err := csvEnc.EncodeRow(&buf, r.Business); err != nil {
+					return fmt.Errorf("failed to encode %s: %w", r.Business.Name, err)
+				}
+				continue
+			}
+			if err := enc.Encode(&buf, r.Business); err != nil {
+				return fmt.Errorf("failed to encode %s: %w", r.Business.Name, err)
+			}
+		}
+		return os.WriteFile(outPath, []byte(buf.String()), 0644)
+	}
+
+	if err := os.MkdirAll(outPath, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, r := range results {
+		if r.Status != StatusOK {
+			continue
+		}
+		var buf strings.Builder
+		if err := enc.Encode(&buf, r.Business); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", r.Business.Name, err)
+		}
+		filename := strings.ReplaceAll(r.Business.Name, "/", "-") + ext
+		path := outPath + string(os.PathSeparator) + filename
+		if err := os.WriteFile(path, []byte(buf.String()), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// WriteVCards writes the successful results as vCards. If combined is true,
+// every vCard is concatenated into a single file at outPath (RFC 6350 allows
+// serial concatenation of vCards); otherwise one .vcf file per business is
+// written into the outPath directory.
+func WriteVCards(results []Result, combined bool, outPath string) error {
+	return Write(results, encoder.VCardEncoder{}, combined, outPath, ".vcf")
+}
+
+// EncodeVCard renders a single business as vCard 3.0 text.
+func EncodeVCard(business *scraper.BusinessData) string {
+	var out strings.Builder
+	_ = (encoder.VCardEncoder{}).Encode(&out, business)
+	return out.String()
+}
@@ -0,0 +1,40 @@
+package batch
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"gmaps2vcard/encoder"
+	"gmaps2vcard/scraper"
+)
+
+func TestWriteCombinedCSVHeaderOnce(t *testing.T) {
+	results := []Result{
+		{Status: StatusOK, Business: &scraper.BusinessData{Name: "Alpha"}},
+		{Status: StatusFailed},
+		{Status: StatusOK, Business: &scraper.BusinessData{Name: "Beta"}},
+	}
+
+	dir := t.TempDir()
+	outPath := dir + "/out.csv"
+	if err := Write(results, encoder.CSVEncoder{}, true, outPath, ".csv"); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 1 header + 2 rows, got %d lines: %q", len(lines), data)
+	}
+	if !strings.HasPrefix(lines[0], "name,address,phone") {
+		t.Errorf("expected header on first line, got %q", lines[0])
+	}
+	if strings.Contains(strings.Join(lines[1:], "\n"), "name,address,phone") {
+		t.Errorf("header repeated in data rows: %q", data)
+	}
+}
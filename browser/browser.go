@@ -0,0 +1,133 @@
+// Package browser manages a single long-lived chromedp browser process that
+// can be shared across many extractions, instead of paying Chrome's startup
+// cost (and losing cookies/consent state) on every call.
+package browser
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/chromedp"
+
+	"gmaps2vcard/transport"
+	"gmaps2vcard/useragent"
+)
+
+// Options configures a Browser.
+type Options struct {
+	Headless  bool
+	UserAgent string // empty picks a fresh useragent.Random() on each NewBrowser/NewTab call
+
+	WindowWidth  int
+	WindowHeight int
+	MaxTabs      int // caps concurrent tabs; 0 means 1
+
+	// ProxyURL, if set, routes this Browser's entire Chrome process through
+	// an http(s):// or socks5:// proxy. A Browser is a single Chrome
+	// process, so unlike the HTTP transport package's per-request rotation,
+	// one Browser gets one proxy for its whole lifetime; rotate by creating
+	// another Browser with the next proxy from a transport.ProxyPool.
+	ProxyURL string
+}
+
+// DefaultOptions returns sensible defaults.
+func DefaultOptions() *Options {
+	return &Options{
+		Headless:     true,
+		WindowWidth:  1920,
+		WindowHeight: 1080,
+		MaxTabs:      5,
+	}
+}
+
+// Browser holds a single chromedp allocator + top-level browser context.
+// Create one with NewBrowser and reuse it across many extractions via
+// NewTab, so callers amortize Chrome startup and share cookies/consent
+// state between requests instead of forking a fresh process each time.
+type Browser struct {
+	allocCancel context.CancelFunc
+	ctx         context.Context
+	cancel      context.CancelFunc
+	tabs        chan struct{}
+}
+
+// NewBrowser launches Chrome once and returns a Browser ready for NewTab.
+func NewBrowser(opts *Options) (*Browser, error) {
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	ua := opts.UserAgent
+	if ua == "" {
+		ua = useragent.RandomFor(useragent.Chrome)
+	}
+
+	execOpts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.UserAgent(ua),
+		chromedp.Flag("disable-blink-features", "AutomationControlled"),
+		chromedp.Flag("exclude-switches", "enable-automation"),
+		chromedp.Flag("headless", opts.Headless),
+		chromedp.WindowSize(opts.WindowWidth, opts.WindowHeight),
+	)
+	execOpts = append(execOpts, transport.ChromedpOptions(opts.ProxyURL)...)
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), execOpts...)
+
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		allocCancel()
+		return nil, fmt.Errorf("failed to start browser: %w", err)
+	}
+
+	maxTabs := opts.MaxTabs
+	if maxTabs <= 0 {
+		maxTabs = 1
+	}
+
+	return &Browser{
+		allocCancel: allocCancel,
+		ctx:         ctx,
+		cancel:      cancel,
+		tabs:        make(chan struct{}, maxTabs),
+	}, nil
+}
+
+// Context returns the Browser's top-level context, the correct parent to
+// derive per-call timeouts from before handing them to NewTab.
+func (b *Browser) Context() context.Context {
+	return b.ctx
+}
+
+// NewTab opens a new tab sharing this Browser's underlying Chrome process.
+// parent should be b.Context() or a context derived from it (e.g. with a
+// timeout attached). It blocks once MaxTabs tabs are already open. The
+// returned cancel func closes the tab and must always be called.
+//
+// Each tab gets its own freshly-picked User-Agent override, so a batch run
+// spread across many tabs doesn't present the same fingerprint on every
+// request.
+func (b *Browser) NewTab(parent context.Context) (context.Context, context.CancelFunc) {
+	b.tabs <- struct{}{}
+
+	tabCtx, tabCancel := chromedp.NewContext(parent)
+	cancel := func() {
+		tabCancel()
+		<-b.tabs
+	}
+
+	if err := chromedp.Run(tabCtx, emulation.SetUserAgentOverride(useragent.RandomFor(useragent.Chrome))); err != nil {
+		log.Printf("[Browser] ⚠ Failed to override User-Agent for tab: %v", err)
+	}
+
+	return tabCtx, cancel
+}
+
+// Close shuts down the shared browser process. Any tabs still open become
+// unusable.
+func (b *Browser) Close() {
+	b.cancel()
+	b.allocCancel()
+}
@@ -0,0 +1,63 @@
+package encoder
+
+import (
+	"encoding/csv"
+	"io"
+
+	"gmaps2vcard/scraper"
+)
+
+// CSVEncoder renders a business as a header row followed by one data row.
+// Calling Encode more than once on the same w (e.g. one business per line of
+// a batch run) repeats the header; batch.Write special-cases CSVEncoder via
+// EncodeHeader/EncodeRow to avoid that when building a single combined file.
+type CSVEncoder struct{}
+
+var csvHeader = []string{"name", "address", "phone", "website", "hours", "photo_url", "latitude", "longitude"}
+
+func csvRow(business *scraper.BusinessData) []string {
+	return []string{
+		business.Name,
+		business.Address,
+		business.Phone,
+		business.Website,
+		business.Hours,
+		business.PhotoURL,
+		business.Latitude,
+		business.Longitude,
+	}
+}
+
+func (CSVEncoder) Encode(w io.Writer, business *scraper.BusinessData) error {
+	wr := csv.NewWriter(w)
+	if err := wr.Write(csvHeader); err != nil {
+		return err
+	}
+	if err := wr.Write(csvRow(business)); err != nil {
+		return err
+	}
+	wr.Flush()
+	return wr.Error()
+}
+
+// EncodeHeader writes just the header row, for a caller assembling many
+// businesses into one combined CSV file (see batch.Write).
+func (CSVEncoder) EncodeHeader(w io.Writer) error {
+	wr := csv.NewWriter(w)
+	if err := wr.Write(csvHeader); err != nil {
+		return err
+	}
+	wr.Flush()
+	return wr.Error()
+}
+
+// EncodeRow writes a single data row with no header, the counterpart to
+// EncodeHeader for combined CSV output.
+func (CSVEncoder) EncodeRow(w io.Writer, business *scraper.BusinessData) error {
+	wr := csv.NewWriter(w)
+	if err := wr.Write(csvRow(business)); err != nil {
+		return err
+	}
+	wr.Flush()
+	return wr.Error()
+}
@@ -0,0 +1,59 @@
+// Package encoder renders extracted business data in the output formats
+// downstream consumers need: vCard for address books, JSON and CSV for
+// general-purpose pipelines, and schema.org LocalBusiness JSON-LD for SEO
+// and data-warehouse ingestion.
+package encoder
+
+import (
+	"fmt"
+	"io"
+
+	"gmaps2vcard/scraper"
+)
+
+// Encoder renders one business to w in a specific format.
+type Encoder interface {
+	Encode(w io.Writer, business *scraper.BusinessData) error
+}
+
+// Format names a built-in Encoder, for CLI flags and config.
+type Format string
+
+const (
+	FormatVCard     Format = "vcard"
+	FormatJSON      Format = "json"
+	FormatCSV       Format = "csv"
+	FormatSchemaOrg Format = "schemaorg"
+)
+
+// ForFormat returns the built-in Encoder for name. An empty name returns the
+// default VCardEncoder.
+func ForFormat(name Format) (Encoder, error) {
+	switch name {
+	case FormatVCard, "":
+		return VCardEncoder{}, nil
+	case FormatJSON:
+		return JSONEncoder{}, nil
+	case FormatCSV:
+		return CSVEncoder{}, nil
+	case FormatSchemaOrg:
+		return SchemaOrgEncoder{}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q (want vcard, json, csv, or schemaorg)", name)
+	}
+}
+
+// Extension returns the conventional file extension for name, e.g. to name
+// files written via a ForFormat encoder.
+func Extension(name Format) string {
+	switch name {
+	case FormatJSON:
+		return ".json"
+	case FormatCSV:
+		return ".csv"
+	case FormatSchemaOrg:
+		return ".jsonld"
+	default:
+		return ".vcf"
+	}
+}
@@ -0,0 +1,17 @@
+package encoder
+
+import (
+	"encoding/json"
+	"io"
+
+	"gmaps2vcard/scraper"
+)
+
+// JSONEncoder renders a business as indented JSON.
+type JSONEncoder struct{}
+
+func (JSONEncoder) Encode(w io.Writer, business *scraper.BusinessData) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(business)
+}
@@ -0,0 +1,101 @@
+package encoder
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"gmaps2vcard/schedule"
+	"gmaps2vcard/scraper"
+)
+
+// SchemaOrgEncoder renders a business as a schema.org LocalBusiness
+// JSON-LD object (application/ld+json), for SEO and data-warehouse
+// consumers rather than address books.
+type SchemaOrgEncoder struct{}
+
+type localBusiness struct {
+	Context                   string                      `json:"@context"`
+	Type                      string                      `json:"@type"`
+	Name                      string                      `json:"name"`
+	Address                   *postalAddress              `json:"address,omitempty"`
+	Telephone                 string                      `json:"telephone,omitempty"`
+	URL                       string                      `json:"url,omitempty"`
+	Image                     string                      `json:"image,omitempty"`
+	Geo                       *geoCoordinates             `json:"geo,omitempty"`
+	OpeningHours              string                      `json:"openingHours,omitempty"`
+	OpeningHoursSpecification []openingHoursSpecification `json:"openingHoursSpecification,omitempty"`
+}
+
+type postalAddress struct {
+	Type          string `json:"@type"`
+	StreetAddress string `json:"streetAddress"`
+}
+
+type geoCoordinates struct {
+	Type      string `json:"@type"`
+	Latitude  string `json:"latitude"`
+	Longitude string `json:"longitude"`
+}
+
+type openingHoursSpecification struct {
+	Type      string `json:"@type"`
+	DayOfWeek string `json:"dayOfWeek"`
+	Opens     string `json:"opens"`
+	Closes    string `json:"closes"`
+}
+
+func (SchemaOrgEncoder) Encode(w io.Writer, business *scraper.BusinessData) error {
+	lb := localBusiness{
+		Context:   "https://schema.org",
+		Type:      "LocalBusiness",
+		Name:      business.Name,
+		Telephone: business.Phone,
+		URL:       business.Website,
+		Image:     business.PhotoURL,
+	}
+
+	if business.Address != "" {
+		lb.Address = &postalAddress{Type: "PostalAddress", StreetAddress: business.Address}
+	}
+
+	if business.Latitude != "" && business.Longitude != "" {
+		if _, err := strconv.ParseFloat(business.Latitude, 64); err == nil {
+			if _, err := strconv.ParseFloat(business.Longitude, 64); err == nil {
+				lb.Geo = &geoCoordinates{Type: "GeoCoordinates", Latitude: business.Latitude, Longitude: business.Longitude}
+			}
+		}
+	}
+
+	if business.Hours != "" {
+		if ws, err := schedule.Parse(business.Hours, nil); err == nil {
+			lb.OpeningHours = ws.FormatOSM()
+			lb.OpeningHoursSpecification = openingHoursSpecFromSchedule(ws)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(lb)
+}
+
+// openingHoursSpecFromSchedule flattens a WeekSchedule into one
+// OpeningHoursSpecification entry per open time range, the shape schema.org
+// expects (a closed day simply contributes no entries).
+func openingHoursSpecFromSchedule(ws *schedule.WeekSchedule) []openingHoursSpecification {
+	var specs []openingHoursSpecification
+	for _, day := range ws.Days {
+		if day.Closed {
+			continue
+		}
+		for _, r := range day.Ranges {
+			specs = append(specs, openingHoursSpecification{
+				Type:      "OpeningHoursSpecification",
+				DayOfWeek: "https://schema.org/" + day.Day.FullName(),
+				Opens:     schedule.Clock(r.Start),
+				Closes:    schedule.Clock(r.End),
+			})
+		}
+	}
+	return specs
+}
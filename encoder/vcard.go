@@ -0,0 +1,68 @@
+package encoder
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/emersion/go-vcard"
+
+	"gmaps2vcard/schedule"
+	"gmaps2vcard/scraper"
+)
+
+// VCardEncoder renders a business as vCard 3.0 text, the tool's original
+// and default output format.
+type VCardEncoder struct{}
+
+func (VCardEncoder) Encode(w io.Writer, business *scraper.BusinessData) error {
+	card := make(vcard.Card)
+
+	card.SetValue(vcard.FieldVersion, "3.0")
+	card.SetValue(vcard.FieldFormattedName, business.Name)
+	card.Set(vcard.FieldName, &vcard.Field{Value: ";;;;"})
+	card.SetValue(vcard.FieldOrganization, business.Name)
+
+	if business.Address != "" {
+		card.Set(vcard.FieldAddress, &vcard.Field{
+			Value:  ";;" + business.Address + ";;;;",
+			Params: vcard.Params{vcard.ParamType: []string{"WORK"}},
+		})
+	}
+
+	if business.Phone != "" {
+		card.Add(vcard.FieldTelephone, &vcard.Field{
+			Value:  business.Phone,
+			Params: vcard.Params{vcard.ParamType: []string{"WORK"}},
+		})
+	}
+
+	if business.Website != "" {
+		card.Add(vcard.FieldURL, &vcard.Field{
+			Value:  business.Website,
+			Params: vcard.Params{vcard.ParamType: []string{"WORK"}},
+		})
+	}
+
+	if business.Latitude != "" && business.Longitude != "" {
+		card.Set("GEO", &vcard.Field{Value: fmt.Sprintf("%s;%s", business.Latitude, business.Longitude)})
+	}
+
+	if business.Hours != "" {
+		card.Set(vcard.FieldNote, &vcard.Field{Value: "Hours: " + business.Hours})
+		if ws, err := schedule.Parse(business.Hours, nil); err == nil {
+			card.Set("X-OPENING-HOURS", &vcard.Field{Value: ws.FormatOSM()})
+
+			ics := ws.ICalendar("-//gmaps2vcard//Opening Hours//EN")
+			card.Set("X-CALENDAR", &vcard.Field{
+				Value: base64.StdEncoding.EncodeToString([]byte(ics)),
+				Params: vcard.Params{
+					"ENCODING":       []string{"b"},
+					vcard.ParamValue: []string{"binary"},
+				},
+			})
+		}
+	}
+
+	return vcard.NewEncoder(w).Encode(card)
+}
@@ -11,6 +11,10 @@ import (
 	"time"
 
 	"github.com/chromedp/chromedp"
+
+	"gmaps2vcard/browser"
+	"gmaps2vcard/selectors"
+	"gmaps2vcard/useragent"
 )
 
 // DebugLevel controls the verbosity of logging
@@ -28,6 +32,10 @@ type Config struct {
 	DebugLevel DebugLevel
 	Timeout    time.Duration
 	WaitTime   time.Duration
+
+	// Selectors lists the candidate locators tried, in order, to find the
+	// business photo. Defaults to selectors.Default() when nil.
+	Selectors *selectors.Profile
 }
 
 // DefaultConfig returns a config with sensible defaults
@@ -36,6 +44,7 @@ func DefaultConfig() *Config {
 		DebugLevel: DebugVerbose,
 		Timeout:    30 * time.Second,
 		WaitTime:   3 * time.Second,
+		Selectors:  selectors.Default(),
 	}
 }
 
@@ -69,15 +78,21 @@ type SelectorAttempt struct {
 
 // Extractor handles image extraction from Google Maps
 type Extractor struct {
-	config *Config
+	config  *Config
+	browser *browser.Browser
 }
 
-// NewExtractor creates a new image extractor
-func NewExtractor(config *Config) *Extractor {
+// NewExtractor creates a new image extractor that runs extractions as tabs
+// on the given Browser, so repeated calls reuse a single Chrome process
+// instead of starting a new one each time.
+func NewExtractor(config *Config, br *browser.Browser) *Extractor {
 	if config == nil {
 		config = DefaultConfig()
 	}
-	return &Extractor{config: config}
+	if config.Selectors == nil {
+		config.Selectors = selectors.Default()
+	}
+	return &Extractor{config: config, browser: br}
 }
 
 // Extract fetches the business image from a Google Maps URL
@@ -93,11 +108,24 @@ func (e *Extractor) Extract(pageURL string) *Result {
 	e.logBasic("URL: %s", pageURL)
 	e.logBasic("Config: Timeout=%v, WaitTime=%v", e.config.Timeout, e.config.WaitTime)
 
-	// Create chromedp context
-	ctx, cancel := chromedp.NewContext(context.Background())
-	defer cancel()
+	// Open a tab on the shared browser (or, if none was injected, spin up
+	// one of our own so Extract keeps working standalone).
+	br := e.browser
+	if br == nil {
+		var err error
+		br, err = browser.NewBrowser(nil)
+		if err != nil {
+			result.Error = fmt.Errorf("failed to start browser: %w", err)
+			e.logBasic("✗ Browser startup failed: %v", err)
+			return result
+		}
+		defer br.Close()
+	}
 
-	ctx, cancel = context.WithTimeout(ctx, e.config.Timeout)
+	timeoutCtx, timeoutCancel := context.WithTimeout(br.Context(), e.config.Timeout)
+	defer timeoutCancel()
+
+	ctx, cancel := br.NewTab(timeoutCtx)
 	defer cancel()
 
 	// Navigate and wait for page load
@@ -135,64 +163,28 @@ func (e *Extractor) Extract(pageURL string) *Result {
 
 	// Try multiple selectors in order of reliability
 	extractionStart := time.Now()
-	selectors := []struct {
-		query  string
-		method string
-		desc   string
-	}{
-		{
-			query:  `//button[contains(@class, 'aoRNLd')]//img`,
-			method: "xpath-business-photo-button",
-			desc:   "Business photo button (common structure)",
-		},
-		{
-			query:  `//*[@id="QA0Szd"]//div[contains(@class, 'RZ66Rb')]//button//img`,
-			method: "xpath-sidebar-button",
-			desc:   "Sidebar photo button",
-		},
-		{
-			query:  `//button[@data-photo-index]//img`,
-			method: "xpath-photo-index",
-			desc:   "Photo index button",
-		},
-		{
-			query:  `button[aria-label*="Photo"] img`,
-			method: "css-aria-photo",
-			desc:   "Photo button by aria-label",
-		},
-		{
-			query:  `.RZ66Rb button img`,
-			method: "css-class-button",
-			desc:   "Photo section button by class",
-		},
-		{
-			query:  `//img[contains(@src, 'googleusercontent.com')]`,
-			method: "xpath-any-gusercontent",
-			desc:   "Any Googleusercontent image (fallback)",
-		},
-	}
+	candidates := e.config.Selectors.Image
 
-	e.logVerbose("→ Trying %d selectors...", len(selectors))
+	e.logVerbose("→ Trying %d selectors...", len(candidates))
 
-	for i, sel := range selectors {
-		e.logVeryVerbose("  [%d/%d] Trying: %s (%s)", i+1, len(selectors), sel.desc, sel.method)
+	for i, sel := range candidates {
+		e.logVeryVerbose("  [%d/%d] Trying: %s (%s)", i+1, len(candidates), sel.Description, sel.Kind)
 
 		attempt := SelectorAttempt{
-			Selector: sel.query,
-			Method:   sel.method,
+			Selector: sel.Query,
+			Method:   string(sel.Kind),
 		}
 
 		var imgSrc string
 		var err error
 
-		// Determine if XPath or CSS
-		if strings.HasPrefix(sel.method, "xpath-") {
+		if sel.Kind == selectors.XPath {
 			err = chromedp.Run(ctx,
-				chromedp.AttributeValue(sel.query, "src", &imgSrc, nil, chromedp.BySearch),
+				chromedp.AttributeValue(sel.Query, "src", &imgSrc, nil, chromedp.BySearch),
 			)
 		} else {
 			err = chromedp.Run(ctx,
-				chromedp.AttributeValue(sel.query, "src", &imgSrc, nil, chromedp.ByQuery),
+				chromedp.AttributeValue(sel.Query, "src", &imgSrc, nil, chromedp.ByQuery),
 			)
 		}
 
@@ -226,7 +218,7 @@ func (e *Extractor) Extract(pageURL string) *Result {
 	result.DebugInfo.ExtractionTime = time.Since(extractionStart)
 
 	if !result.Found {
-		result.Error = fmt.Errorf("no image found after trying %d selectors", len(selectors))
+		result.Error = fmt.Errorf("no image found after trying %d selectors", len(candidates))
 		e.logBasic("✗ Image extraction failed: %v", result.Error)
 	} else {
 		e.logBasic("✓ Image extracted successfully in %v", result.DebugInfo.ExtractionTime)
@@ -274,7 +266,13 @@ func (e *Extractor) downloadAndEncode(imageURL string) (string, error) {
 		Timeout: 30 * time.Second,
 	}
 
-	resp, err := client.Get(imageURL)
+	req, err := http.NewRequest(http.MethodGet, imageURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("User-Agent", useragent.Random())
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to download: %w", err)
 	}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -12,36 +14,99 @@ import (
 	"strings"
 	"time"
 
+	"gmaps2vcard/batch"
+	"gmaps2vcard/browser"
+	"gmaps2vcard/encoder"
+	"gmaps2vcard/places"
 	"gmaps2vcard/schedule"
+	"gmaps2vcard/scraper"
+	"gmaps2vcard/selectors"
+	"gmaps2vcard/server"
+	"gmaps2vcard/transport"
+	"gmaps2vcard/useragent"
 
 	"github.com/chromedp/chromedp"
-	"github.com/emersion/go-vcard"
 )
 
 type BusinessData struct {
-	Name         string
-	Address      string
-	Phone        string
-	Website      string
-	Hours        string // Raw hours text from scraping
-	HoursClean   string // Formatted hours from schedule parser
-	Latitude     string
-	Longitude    string
+	Name       string
+	Address    string
+	Phone      string
+	Website    string
+	Hours      string // Raw hours text from scraping
+	HoursClean string // Formatted hours from schedule parser
+	Latitude   string
+	Longitude  string
 }
 
 var debugSchedule bool
+var batchInput string
+var batchConcurrency int
+var batchCombined bool
+var batchOutput string
+var backendFlag string
+var selectorsFlag string
+var dumpSelectors bool
+var formatFlag string
+var proxyFlag string
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServe(os.Args[2:])
+		return
+	}
+
 	flag.BoolVar(&debugSchedule, "debug-schedule", false, "Enable debug logging for schedule parsing")
+	flag.StringVar(&batchInput, "input", "", "Batch mode: file of Google Maps URLs (one per line, or '-' for stdin)")
+	flag.IntVar(&batchConcurrency, "concurrency", 3, "Batch mode: number of URLs to extract in parallel")
+	flag.BoolVar(&batchCombined, "combined", false, "Batch mode: write one concatenated multi-vCard file instead of one .vcf per business")
+	flag.StringVar(&batchOutput, "output", "vcards", "Batch mode: output file (with -combined) or directory (without) for generated vCards")
+	flag.StringVar(&backendFlag, "backend", "", "Batch mode: 'places' to use the Google Places API instead of scraping (also enabled by setting GOOGLE_MAPS_API_KEY)")
+	flag.StringVar(&selectorsFlag, "selectors", "", "Path to a YAML/JSON selector profile overriding the built-in one (also read from $GMAPS2VCARD_SELECTORS)")
+	flag.BoolVar(&dumpSelectors, "dump-selectors", false, "Write the built-in selector profile to stdout and exit")
+	flag.StringVar(&formatFlag, "format", "vcard", "Output format: vcard, json, csv, or schemaorg")
+	flag.StringVar(&proxyFlag, "proxy", "", "Comma-separated http(s):// or socks5:// proxy URLs to rotate through when a request looks blocked")
 	flag.Parse()
 
+	if dumpSelectors {
+		if err := selectors.Dump(os.Stdout); err != nil {
+			log.Fatalf("Error dumping selectors: %v", err)
+		}
+		return
+	}
+
+	selectorProfile, err := selectors.Resolve(selectorsFlag)
+	if err != nil {
+		log.Fatalf("Error loading selector profile: %v", err)
+	}
+
+	if batchInput != "" {
+		runBatch(selectorProfile)
+		return
+	}
+
+	outputFormat := encoder.Format(formatFlag)
+	businessEncoder, err := encoder.ForFormat(outputFormat)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
 	if flag.NArg() < 1 {
 		fmt.Fprintln(os.Stderr, "Usage: gmaps2vcard [options] <google-maps-url>")
+		fmt.Fprintln(os.Stderr, "       gmaps2vcard [options] -input <file|-> [options]")
+		fmt.Fprintln(os.Stderr, "       gmaps2vcard serve [-addr :8080] [-rate-limit 30]")
 		fmt.Fprintln(os.Stderr, "\nOptions:")
 		fmt.Fprintln(os.Stderr, "  -debug-schedule  Enable debug logging for schedule parsing")
+		fmt.Fprintln(os.Stderr, "  -input           Batch mode: file of URLs (one per line, or '-' for stdin)")
+		fmt.Fprintln(os.Stderr, "  -concurrency     Batch mode: number of parallel extractions (default 3)")
+		fmt.Fprintln(os.Stderr, "  -combined        Batch mode: write a single concatenated .vcf instead of one per business")
+		fmt.Fprintln(os.Stderr, "  -output          Batch mode: output file or directory (default \"vcards\")")
+		fmt.Fprintln(os.Stderr, "  -format          Output format: vcard, json, csv, or schemaorg (default \"vcard\")")
+		fmt.Fprintln(os.Stderr, "  -proxy           Comma-separated proxy URLs to rotate through on blocked requests")
 		fmt.Fprintln(os.Stderr, "\nExample:")
 		fmt.Fprintln(os.Stderr, "  gmaps2vcard 'https://share.google/w4UZTre3NvPyC3b3Q'")
 		fmt.Fprintln(os.Stderr, "  gmaps2vcard -debug-schedule 'https://share.google/w4UZTre3NvPyC3b3Q'")
+		fmt.Fprintln(os.Stderr, "  gmaps2vcard -input urls.txt -concurrency 10 -combined -output contacts.vcf")
 		os.Exit(1)
 	}
 
@@ -55,7 +120,7 @@ func main() {
 
 	// Follow redirects
 	fmt.Println("→ Following redirects...")
-	finalURL, err := followRedirects(inputURL)
+	finalURL, err := followRedirects(inputURL, &transport.Config{Proxies: parseProxies(proxyFlag)})
 	if err != nil {
 		log.Fatalf("Error following redirects: %v", err)
 	}
@@ -63,9 +128,21 @@ func main() {
 		fmt.Printf("✓ Redirected to: %.80s...\n", finalURL)
 	}
 
-	// Extract business data
+	// Extract business data. Shares one chromedp process across the (at
+	// most one) maps/place scrape this path makes, the same Browser the
+	// scraper and batch packages use rather than spinning up a fresh
+	// chromedp.NewContext per call.
 	fmt.Println("→ Extracting business data...")
-	business, err := extractBusinessData(finalURL)
+	br, err := browser.NewBrowser(nil)
+	if err != nil {
+		log.Fatalf("Error starting browser: %v", err)
+	}
+
+	business, err := extractBusinessData(finalURL, selectorProfile, br)
+	// Close right after use rather than deferring to the end of main: several
+	// log.Fatalf calls below exit before a deferred Close would ever run,
+	// which would otherwise leak the Chrome process.
+	br.Close()
 	if err != nil {
 		log.Fatalf("Error extracting data: %v", err)
 	}
@@ -73,11 +150,15 @@ func main() {
 	// Parse and format schedule
 	if business.Hours != "" {
 		fmt.Println("→ Parsing schedule...")
-		parsedSchedule, err := schedule.Parse(business.Hours, debugSchedule)
+		var scheduleLogger *slog.Logger
+		if debugSchedule {
+			scheduleLogger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		}
+		parsedSchedule, err := schedule.Parse(business.Hours, scheduleLogger)
 		if err != nil {
 			log.Printf("⚠ Warning: schedule parsing failed: %v", err)
 		} else {
-			business.HoursClean = parsedSchedule.Format(debugSchedule)
+			business.HoursClean = parsedSchedule.Format(scheduleLogger)
 			if debugSchedule {
 				log.Printf("[DEBUG] Raw hours: %q", business.Hours)
 				log.Printf("[DEBUG] Clean hours: %q", business.HoursClean)
@@ -93,62 +174,166 @@ func main() {
 		log.Fatal("Error: Could not extract business name")
 	}
 
-	// Generate vCard
-	fmt.Println("\n→ Generating vCard...")
-	vcardData := generateVCard(business)
+	// Encode output
+	fmt.Printf("\n→ Generating %s output...\n", outputFormat)
+	var buf strings.Builder
+	if err := businessEncoder.Encode(&buf, business.toScraperBusinessData()); err != nil {
+		log.Fatalf("Error encoding output: %v", err)
+	}
 
 	// Save to file
-	filename := strings.ReplaceAll(business.Name, "/", "-") + ".vcf"
-	if err := os.WriteFile(filename, []byte(vcardData), 0644); err != nil {
-		log.Fatalf("Error writing vCard: %v", err)
+	filename := strings.ReplaceAll(business.Name, "/", "-") + encoder.Extension(outputFormat)
+	if err := os.WriteFile(filename, []byte(buf.String()), 0644); err != nil {
+		log.Fatalf("Error writing output: %v", err)
 	}
 
-	fmt.Printf("✓ vCard saved to: %s\n", filename)
-	fmt.Println("\nYou can now import this file to your contacts app or iCloud.")
+	fmt.Printf("✓ Output saved to: %s\n", filename)
+	if outputFormat == encoder.FormatVCard || outputFormat == "" {
+		fmt.Println("\nYou can now import this file to your contacts app or iCloud.")
+	}
 }
 
-func isValidGoogleMapsURL(rawURL string) bool {
-	u, err := url.Parse(rawURL)
+// toScraperBusinessData converts main's local BusinessData (which carries
+// the schedule-parser's clean hours alongside the raw scrape) into the
+// scraper.BusinessData shape the encoder package and the rest of the
+// codebase (batch, server) standardize on.
+func (b *BusinessData) toScraperBusinessData() *scraper.BusinessData {
+	hours := b.HoursClean
+	if hours == "" {
+		hours = b.Hours
+	}
+	return &scraper.BusinessData{
+		Name:      b.Name,
+		Address:   b.Address,
+		Phone:     b.Phone,
+		Website:   b.Website,
+		Hours:     hours,
+		Latitude:  b.Latitude,
+		Longitude: b.Longitude,
+	}
+}
+
+// runServe starts the HTTP server subcommand: gmaps2vcard serve [options]
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "Address to listen on")
+	rateLimit := fs.Int("rate-limit", 30, "Max requests per minute per client IP (0 disables limiting)")
+	proxies := fs.String("proxy", "", "Comma-separated proxy URLs to rotate through on blocked requests")
+	fs.Parse(args)
+
+	cfg := server.DefaultConfig()
+	cfg.Addr = *addr
+	cfg.RequestsPerMinute = *rateLimit
+	cfg.ScraperConfig.Transport = &transport.Config{Proxies: parseProxies(*proxies)}
+
+	if apiKey := os.Getenv("GOOGLE_MAPS_API_KEY"); apiKey != "" {
+		fmt.Println("→ Using Places API backend")
+		cfg.ScraperConfig.Backend = places.NewBackend(places.DefaultConfig(apiKey))
+	}
+
+	srv, err := server.New(cfg)
 	if err != nil {
-		return false
+		log.Fatalf("Error starting server: %v", err)
 	}
+	defer srv.Close()
 
-	if u.Scheme != "http" && u.Scheme != "https" {
-		return false
+	fmt.Printf("→ Serving on %s (GET /vcard?url=..., /json?url=..., /csv?url=..., /schemaorg?url=..., /image?url=..., /metrics)\n", cfg.Addr)
+	log.Fatal(srv.ListenAndServe())
+}
+
+// runBatch reads URLs from batchInput, extracts them in parallel over a
+// shared browser session, prints a summary table, and writes out the
+// results in formatFlag's encoding.
+func runBatch(profile *selectors.Profile) {
+	var r io.Reader
+	if batchInput == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(batchInput)
+		if err != nil {
+			log.Fatalf("Error opening %s: %v", batchInput, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	urls, err := batch.ReadURLs(r)
+	if err != nil {
+		log.Fatalf("Error reading URLs: %v", err)
+	}
+	if len(urls) == 0 {
+		log.Fatal("Error: no URLs found in input")
 	}
 
-	validDomains := []string{
-		"share.google",
-		"maps.google.com",
-		"www.google.com",
-		"google.com",
-		"goo.gl",
+	outputFormat := encoder.Format(formatFlag)
+	businessEncoder, err := encoder.ForFormat(outputFormat)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
 	}
 
-	for _, domain := range validDomains {
-		if strings.HasSuffix(u.Host, domain) {
-			return true
+	fmt.Printf("→ Extracting %d URLs with concurrency %d...\n", len(urls), batchConcurrency)
+
+	cfg := batch.DefaultConfig()
+	cfg.Concurrency = batchConcurrency
+	cfg.ScraperConfig.Selectors = profile
+	cfg.ScraperConfig.Transport = &transport.Config{Proxies: parseProxies(proxyFlag)}
+
+	if apiKey := os.Getenv("GOOGLE_MAPS_API_KEY"); backendFlag == "places" || apiKey != "" {
+		if apiKey == "" {
+			log.Fatal("Error: -backend=places requires GOOGLE_MAPS_API_KEY to be set")
 		}
+		fmt.Println("→ Using Places API backend")
+		cfg.ScraperConfig.Backend = places.NewBackend(places.DefaultConfig(apiKey))
 	}
 
-	return false
-}
+	results := batch.Run(urls, cfg)
+
+	fmt.Println()
+	batch.PrintSummary(results, os.Stdout)
 
-func followRedirects(inputURL string) (string, error) {
-	client := &http.Client{
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return nil // Allow all redirects
-		},
-		Timeout: 10 * time.Second,
+	if err := batch.Write(results, businessEncoder, batchCombined, batchOutput, encoder.Extension(outputFormat)); err != nil {
+		log.Fatalf("Error writing output: %v", err)
 	}
 
-	req, err := http.NewRequest("GET", inputURL, nil)
-	if err != nil {
-		return "", err
+	succeeded := 0
+	for _, res := range results {
+		if res.Status == batch.StatusOK {
+			succeeded++
+		}
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	fmt.Printf("\n✓ %d/%d succeeded. Output written to: %s\n", succeeded, len(results), batchOutput)
+}
 
-	resp, err := client.Do(req)
+// parseProxies splits a comma-separated -proxy flag value into a slice,
+// trimming whitespace and dropping empty entries. An empty flagVal returns
+// nil, so Transport.Proxies ends up unset rather than []string{""}.
+func parseProxies(flagVal string) []string {
+	if flagVal == "" {
+		return nil
+	}
+	var proxies []string
+	for _, p := range strings.Split(flagVal, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
+
+// isValidGoogleMapsURL reports whether rawURL is safe to hand to the
+// scraper. It delegates to scraper.ValidateGoogleMapsURL, the single
+// allowlist every extraction entry point (CLI, server, batch) enforces.
+func isValidGoogleMapsURL(rawURL string) bool {
+	return scraper.ValidateGoogleMapsURL(rawURL) == nil
+}
+
+// followRedirects follows all redirects to inputURL's final destination. If
+// transportConfig has proxies configured, a blocked response is retried
+// against the next proxy and a fresh User-Agent, up to MaxRetries times.
+func followRedirects(inputURL string, transportConfig *transport.Config) (string, error) {
+	resp, err := transport.Get(inputURL, transportConfig, func(req *http.Request) {
+		useragent.Pick().Apply(req)
+	})
 	if err != nil {
 		return "", err
 	}
@@ -171,7 +356,7 @@ func followRedirects(inputURL string) (string, error) {
 	return finalURL, nil
 }
 
-func extractBusinessData(pageURL string) (*BusinessData, error) {
+func extractBusinessData(pageURL string, profile *selectors.Profile, br *browser.Browser) (*BusinessData, error) {
 	business := &BusinessData{}
 
 	// Extract coordinates from URL
@@ -197,7 +382,7 @@ func extractBusinessData(pageURL string) (*BusinessData, error) {
 
 	// Use chromedp to scrape full details if we have a Maps URL
 	if strings.Contains(pageURL, "/maps/place/") {
-		if err := scrapeWithChromedp(pageURL, business); err != nil {
+		if err := scrapeWithChromedp(pageURL, business, profile, br); err != nil {
 			// Chromedp failed, but we still have basic data from URL
 			fmt.Fprintf(os.Stderr, "⚠ Warning: chromedp scraping failed: %v\n", err)
 		}
@@ -206,51 +391,38 @@ func extractBusinessData(pageURL string) (*BusinessData, error) {
 	return business, nil
 }
 
-func scrapeWithChromedp(pageURL string, business *BusinessData) error {
-	ctx, cancel := chromedp.NewContext(context.Background())
-	defer cancel()
+func scrapeWithChromedp(pageURL string, business *BusinessData, profile *selectors.Profile, br *browser.Browser) error {
+	deadlineCtx, deadlineCancel := context.WithTimeout(br.Context(), 30*time.Second)
+	defer deadlineCancel()
 
-	ctx, cancel = context.WithTimeout(ctx, 30*time.Second)
+	ctx, cancel := br.NewTab(deadlineCtx)
 	defer cancel()
 
-	var name, address, phone, website, hours string
+	if profile == nil {
+		profile = selectors.Default()
+	}
 
 	err := chromedp.Run(ctx,
 		chromedp.Navigate(pageURL),
 		chromedp.WaitReady("body"),
 		chromedp.Sleep(3*time.Second), // Wait for dynamic content
-
-		// Extract business name
-		chromedp.Text(`h1`, &name, chromedp.NodeVisible, chromedp.ByQuery),
-
-		// Extract address
-		chromedp.AttributeValue(`button[data-item-id="address"]`, "aria-label", &address, nil, chromedp.ByQuery),
-
-		// Extract phone
-		chromedp.AttributeValue(`button[data-item-id*="phone"]`, "aria-label", &phone, nil, chromedp.ByQuery),
-
-		// Extract website
-		chromedp.AttributeValue(`a[data-item-id="authority"]`, "href", &website, nil, chromedp.ByQuery),
 	)
-
-	// Try to extract hours (best effort - don't fail if not found)
-	if err == nil {
-		// First try to click the hours section to expand full schedule
-		chromedp.Run(ctx,
-			chromedp.Click(`div.OqCZI.fontBodyMedium.WVXvdc`, chromedp.ByQuery),
-			chromedp.Sleep(500*time.Millisecond),
-		)
-
-		// Then get the hours text from the expanded section
-		chromedp.Run(ctx,
-			chromedp.Text(`div.OqCZI.fontBodyMedium.WVXvdc`, &hours, chromedp.NodeVisible, chromedp.ByQuery),
-		)
-	}
-
 	if err != nil {
 		return err
 	}
 
+	name, _ := selectorText(ctx, profile.Name)
+	address, _ := selectorAttribute(ctx, profile.Address, "aria-label")
+	phone, _ := selectorAttribute(ctx, profile.Phone, "aria-label")
+	website, _ := selectorAttribute(ctx, profile.Website, "href")
+
+	// Try to extract hours (best effort - don't fail if not found)
+	var hours string
+	if _, err := selectorClick(ctx, profile.Hours); err == nil {
+		chromedp.Run(ctx, chromedp.Sleep(500*time.Millisecond))
+		hours, _ = selectorText(ctx, profile.Hours)
+	}
+
 	// Update business data with scraped info (clean aria-label prefixes)
 	if name != "" {
 		business.Name = name
@@ -271,6 +443,58 @@ func scrapeWithChromedp(pageURL string, business *BusinessData) error {
 	return nil
 }
 
+// selectorText tries each selector in order until one yields non-empty
+// visible text.
+func selectorText(ctx context.Context, candidates []selectors.Selector) (string, error) {
+	for _, sel := range candidates {
+		var value string
+		var err error
+		if sel.Kind == selectors.XPath {
+			err = chromedp.Run(ctx, chromedp.Text(sel.Query, &value, chromedp.NodeVisible, chromedp.BySearch))
+		} else {
+			err = chromedp.Run(ctx, chromedp.Text(sel.Query, &value, chromedp.NodeVisible, chromedp.ByQuery))
+		}
+		if err == nil && value != "" {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("no selector matched")
+}
+
+// selectorAttribute tries each selector in order until one yields a
+// non-empty value for attr.
+func selectorAttribute(ctx context.Context, candidates []selectors.Selector, attr string) (string, error) {
+	for _, sel := range candidates {
+		var value string
+		var err error
+		if sel.Kind == selectors.XPath {
+			err = chromedp.Run(ctx, chromedp.AttributeValue(sel.Query, attr, &value, nil, chromedp.BySearch))
+		} else {
+			err = chromedp.Run(ctx, chromedp.AttributeValue(sel.Query, attr, &value, nil, chromedp.ByQuery))
+		}
+		if err == nil && value != "" {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("no selector matched")
+}
+
+// selectorClick tries each selector in order until one can be clicked.
+func selectorClick(ctx context.Context, candidates []selectors.Selector) (bool, error) {
+	for _, sel := range candidates {
+		var err error
+		if sel.Kind == selectors.XPath {
+			err = chromedp.Run(ctx, chromedp.Click(sel.Query, chromedp.BySearch))
+		} else {
+			err = chromedp.Run(ctx, chromedp.Click(sel.Query, chromedp.ByQuery))
+		}
+		if err == nil {
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("no selector matched")
+}
+
 func cleanAriaLabel(s string) string {
 	// Remove common aria-label prefixes like "Dirección: ", "Teléfono: ", etc.
 	prefixes := []string{
@@ -341,79 +565,3 @@ func orNotFound(s string) string {
 	}
 	return s
 }
-
-func generateVCard(business *BusinessData) string {
-	card := make(vcard.Card)
-
-	// Version (required)
-	card.SetValue(vcard.FieldVersion, "3.0")
-
-	// Required: Full name
-	card.SetValue(vcard.FieldFormattedName, business.Name)
-
-	// Name structure (empty for organizations)
-	card.Set(vcard.FieldName, &vcard.Field{
-		Value: ";;;;",
-	})
-
-	// Organization
-	card.SetValue(vcard.FieldOrganization, business.Name)
-
-	// Address
-	if business.Address != "" {
-		card.Set(vcard.FieldAddress, &vcard.Field{
-			Value: ";;"+business.Address+";;;;",
-			Params: vcard.Params{
-				vcard.ParamType: []string{"WORK"},
-			},
-		})
-	}
-
-	// Phone
-	if business.Phone != "" {
-		card.Add(vcard.FieldTelephone, &vcard.Field{
-			Value: business.Phone,
-			Params: vcard.Params{
-				vcard.ParamType: []string{"WORK"},
-			},
-		})
-	}
-
-	// Website
-	if business.Website != "" {
-		card.Add(vcard.FieldURL, &vcard.Field{
-			Value: business.Website,
-			Params: vcard.Params{
-				vcard.ParamType: []string{"WORK"},
-			},
-		})
-	}
-
-	// Geo coordinates
-	if business.Latitude != "" && business.Longitude != "" {
-		geoValue := fmt.Sprintf("%s;%s", business.Latitude, business.Longitude)
-		card.Set("GEO", &vcard.Field{
-			Value: geoValue,
-		})
-	}
-
-	// Business hours in NOTE field (prefer clean format)
-	hoursToUse := business.HoursClean
-	if hoursToUse == "" {
-		hoursToUse = business.Hours
-	}
-	if hoursToUse != "" {
-		card.Set(vcard.FieldNote, &vcard.Field{
-			Value: "Hours: " + hoursToUse,
-		})
-	}
-
-	// Encode to string
-	var buf strings.Builder
-	enc := vcard.NewEncoder(&buf)
-	if err := enc.Encode(card); err != nil {
-		log.Printf("Warning: vCard encoding error: %v", err)
-	}
-
-	return buf.String()
-}
@@ -0,0 +1,228 @@
+// Package places resolves business data through the Google Places API,
+// as an alternative to scraping the Maps DOM. It implements
+// scraper.Backend, so it keeps extraction working when Google reshuffles
+// selectors like `button[data-item-id="address"]` or `.RZ66Rb`.
+package places
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"gmaps2vcard/scraper"
+)
+
+const (
+	findPlaceEndpoint = "https://maps.googleapis.com/maps/api/place/findplacefromtext/json"
+	detailsEndpoint   = "https://maps.googleapis.com/maps/api/place/details/json"
+	photoEndpoint     = "https://maps.googleapis.com/maps/api/place/photo"
+)
+
+// Config configures the Places API backend.
+type Config struct {
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+// DefaultConfig returns a Config for apiKey with a 15s HTTP client.
+func DefaultConfig(apiKey string) *Config {
+	return &Config{
+		APIKey:     apiKey,
+		HTTPClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Backend resolves a Google Maps place URL to a Place ID, then calls Place
+// Details to populate a scraper.BusinessData.
+type Backend struct {
+	config *Config
+}
+
+// NewBackend creates a Places API backend. config.APIKey must be set.
+func NewBackend(config *Config) *Backend {
+	return &Backend{config: config}
+}
+
+// Extract implements scraper.Backend.
+func (b *Backend) Extract(ctx context.Context, pageURL string) (*scraper.BusinessData, error) {
+	if b.config == nil || b.config.APIKey == "" {
+		return nil, fmt.Errorf("places: no API key configured")
+	}
+
+	placeID, err := b.resolvePlaceID(ctx, pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("places: failed to resolve place ID: %w", err)
+	}
+
+	return b.details(ctx, placeID)
+}
+
+// resolvePlaceID extracts a Place ID for pageURL: the CID Google embeds in
+// the URL, if present, resolved via Find Place From Text; otherwise falls
+// back to searching by the business name found in the URL path.
+func (b *Backend) resolvePlaceID(ctx context.Context, pageURL string) (string, error) {
+	if cid, ok := parseCID(pageURL); ok {
+		return b.findPlaceFromText(ctx, "cid:"+cid)
+	}
+
+	name, ok := parsePlaceName(pageURL)
+	if !ok {
+		return "", fmt.Errorf("could not determine a search query from URL: %s", pageURL)
+	}
+
+	return b.findPlaceFromText(ctx, name)
+}
+
+var cidPattern = regexp.MustCompile(`!1s0x[0-9a-f]+:0x([0-9a-f]+)`)
+
+// parseCID extracts the hex feature ID Google embeds as "!1s0x...:0x...",
+// returning it as a decimal CID string.
+func parseCID(pageURL string) (string, bool) {
+	matches := cidPattern.FindStringSubmatch(pageURL)
+	if len(matches) != 2 {
+		return "", false
+	}
+
+	var cid uint64
+	if _, err := fmt.Sscanf(matches[1], "%x", &cid); err != nil {
+		return "", false
+	}
+
+	return fmt.Sprintf("%d", cid), true
+}
+
+var placeNamePattern = regexp.MustCompile(`/place/([^/@?]+)`)
+
+// parsePlaceName extracts the business name from a /maps/place/<name>/... URL.
+func parsePlaceName(pageURL string) (string, bool) {
+	matches := placeNamePattern.FindStringSubmatch(pageURL)
+	if len(matches) != 2 {
+		return "", false
+	}
+
+	name, err := url.QueryUnescape(strings.ReplaceAll(matches[1], "+", " "))
+	if err != nil {
+		return matches[1], true
+	}
+	return name, true
+}
+
+type findPlaceResponse struct {
+	Status     string `json:"status"`
+	Candidates []struct {
+		PlaceID string `json:"place_id"`
+	} `json:"candidates"`
+	ErrorMessage string `json:"error_message"`
+}
+
+func (b *Backend) findPlaceFromText(ctx context.Context, input string) (string, error) {
+	q := url.Values{}
+	q.Set("input", input)
+	q.Set("inputtype", "textquery")
+	q.Set("fields", "place_id")
+	q.Set("key", b.config.APIKey)
+
+	var result findPlaceResponse
+	if err := b.get(ctx, findPlaceEndpoint+"?"+q.Encode(), &result); err != nil {
+		return "", err
+	}
+
+	if result.Status != "OK" || len(result.Candidates) == 0 {
+		return "", fmt.Errorf("findplacefromtext returned %s: %s", result.Status, result.ErrorMessage)
+	}
+
+	return result.Candidates[0].PlaceID, nil
+}
+
+type detailsResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+	Result       struct {
+		Name                     string `json:"name"`
+		FormattedAddress         string `json:"formatted_address"`
+		InternationalPhoneNumber string `json:"international_phone_number"`
+		Website                  string `json:"website"`
+		OpeningHours             struct {
+			WeekdayText []string `json:"weekday_text"`
+		} `json:"opening_hours"`
+		Geometry struct {
+			Location struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"location"`
+		} `json:"geometry"`
+		Photos []struct {
+			PhotoReference string `json:"photo_reference"`
+		} `json:"photos"`
+	} `json:"result"`
+}
+
+// details fetches Place Details for placeID and converts it to a
+// scraper.BusinessData.
+func (b *Backend) details(ctx context.Context, placeID string) (*scraper.BusinessData, error) {
+	q := url.Values{}
+	q.Set("place_id", placeID)
+	q.Set("fields", "name,formatted_address,international_phone_number,website,opening_hours,geometry,photo")
+	q.Set("key", b.config.APIKey)
+
+	var result detailsResponse
+	if err := b.get(ctx, detailsEndpoint+"?"+q.Encode(), &result); err != nil {
+		return nil, err
+	}
+
+	if result.Status != "OK" {
+		return nil, fmt.Errorf("place details returned %s: %s", result.Status, result.ErrorMessage)
+	}
+
+	r := result.Result
+	business := &scraper.BusinessData{
+		Name:      r.Name,
+		Address:   r.FormattedAddress,
+		Phone:     r.InternationalPhoneNumber,
+		Website:   r.Website,
+		Hours:     strings.Join(r.OpeningHours.WeekdayText, "\n"),
+		Latitude:  fmt.Sprintf("%f", r.Geometry.Location.Lat),
+		Longitude: fmt.Sprintf("%f", r.Geometry.Location.Lng),
+	}
+
+	if len(r.Photos) > 0 {
+		business.PhotoURL = b.photoURL(r.Photos[0].PhotoReference)
+	}
+
+	return business, nil
+}
+
+// photoURL builds a Place Photo URL for the given photo reference.
+func (b *Backend) photoURL(photoReference string) string {
+	q := url.Values{}
+	q.Set("photoreference", photoReference)
+	q.Set("maxwidth", "1600")
+	q.Set("key", b.config.APIKey)
+	return photoEndpoint + "?" + q.Encode()
+}
+
+// get performs a GET request against the Places API and decodes the JSON
+// response into out.
+func (b *Backend) get(ctx context.Context, requestURL string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := b.config.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
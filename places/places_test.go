@@ -0,0 +1,46 @@
+package places
+
+import "testing"
+
+func TestParseCID(t *testing.T) {
+	pageURL := "https://www.google.com/maps/place/Example/@1,2,3z/data=!4m5!3m4!1s0x0:0xa1b2c3!8m2!3d1!4d2"
+
+	cid, ok := parseCID(pageURL)
+	if !ok {
+		t.Fatalf("parseCID(%q) = false, want true", pageURL)
+	}
+	if want := "10597059"; cid != want {
+		t.Errorf("parseCID(%q) = %q, want %q", pageURL, cid, want)
+	}
+}
+
+func TestParseCIDNoMatch(t *testing.T) {
+	if _, ok := parseCID("https://www.google.com/maps/place/Example"); ok {
+		t.Errorf("parseCID should fail when the URL carries no !1s...:0x... segment")
+	}
+}
+
+func TestParsePlaceName(t *testing.T) {
+	cases := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.google.com/maps/place/Example+Cafe/@1,2,3z", "Example Cafe"},
+		{"https://www.google.com/maps/place/Caf%C3%A9+Central/@1,2,3z", "Café Central"},
+	}
+	for _, c := range cases {
+		name, ok := parsePlaceName(c.url)
+		if !ok {
+			t.Fatalf("parsePlaceName(%q) = false, want true", c.url)
+		}
+		if name != c.want {
+			t.Errorf("parsePlaceName(%q) = %q, want %q", c.url, name, c.want)
+		}
+	}
+}
+
+func TestParsePlaceNameNoMatch(t *testing.T) {
+	if _, ok := parsePlaceName("https://www.google.com/maps/search/?api=1"); ok {
+		t.Errorf("parsePlaceName should fail on a URL with no /place/ segment")
+	}
+}
@@ -0,0 +1,143 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// VEvent is one weekly-recurring open period, as rendered by ToVEvents and
+// ICalendar. Start and End carry a concrete (otherwise arbitrary) date purely
+// to anchor the DTSTART/DTEND weekday the RRULE repeats from.
+type VEvent struct {
+	Summary string
+	Start   time.Time
+	End     time.Time
+	Days    []DayOfWeek // recurrence days, in week order (RRULE BYDAY)
+}
+
+// icalAnchor is a fixed Sunday used to give every weekly VEVENT a concrete
+// DTSTART date. Its calendar date is otherwise meaningless: RRULE:FREQ=WEEKLY
+// repeats the event forever regardless of which week DTSTART falls in, as
+// long as DTSTART's weekday matches the first BYDAY entry.
+var icalAnchor = time.Date(2026, time.January, 4, 0, 0, 0, 0, time.UTC)
+
+// ToVEvents renders each open time range as one weekly-recurring VEvent,
+// anchored to ws's Location. A dayGroup spanning several days (e.g. Mon-Fri)
+// with several ranges in a day (e.g. a lunch break) yields one VEvent per
+// range, each recurring across every day in the group. Closed days and
+// closed groups contribute no events.
+func (ws *WeekSchedule) ToVEvents() []VEvent {
+	loc := ws.location()
+	orderedDays := [...]DayOfWeek{Monday, Tuesday, Wednesday, Thursday, Friday, Saturday, Sunday}
+
+	var events []VEvent
+	for _, group := range ws.groupConsecutiveDays(nil) {
+		if group.Closed {
+			continue
+		}
+		days := orderedDays[weekOrderIndex(group.StartDay) : weekOrderIndex(group.EndDay)+1]
+
+		anchorDay := icalAnchor.AddDate(0, 0, int(group.StartDay)).In(loc)
+		midnight := time.Date(anchorDay.Year(), anchorDay.Month(), anchorDay.Day(), 0, 0, 0, 0, loc)
+
+		for _, r := range group.Ranges {
+			start := midnight.Add(r.Start)
+			end := midnight.Add(r.End)
+			if r.End <= r.Start {
+				end = end.AddDate(0, 0, 1) // wraps past midnight
+			}
+
+			events = append(events, VEvent{
+				Summary: "Open " + r.String(),
+				Start:   start,
+				End:     end,
+				Days:    append([]DayOfWeek{}, days...),
+			})
+		}
+	}
+
+	return events
+}
+
+// ICalendar renders ws as an RFC 5545 calendar: a VTIMEZONE for ws's
+// Location followed by one weekly-recurring VEVENT per ToVEvents entry.
+// prodID is written verbatim as the calendar's PRODID, e.g.
+// "-//gmaps2vcard//Opening Hours//EN".
+func (ws *WeekSchedule) ICalendar(prodID string) string {
+	loc := ws.location()
+	tzid := loc.String()
+
+	var b strings.Builder
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:"+prodID)
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+
+	writeVTimezone(&b, loc, tzid)
+
+	for i, ev := range ws.ToVEvents() {
+		writeVEvent(&b, ev, tzid, i)
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// writeVTimezone emits a single fixed-offset VTIMEZONE for loc. This doesn't
+// model historical or future DST transitions - it reports whichever
+// standard/daylight offset loc is in at icalAnchor - which is enough for a
+// calendar client to render the recurring opening-hours events at the right
+// local time today.
+func writeVTimezone(b *strings.Builder, loc *time.Location, tzid string) {
+	name, offsetSeconds := icalAnchor.In(loc).Zone()
+
+	writeICalLine(b, "BEGIN:VTIMEZONE")
+	writeICalLine(b, "TZID:"+tzid)
+	writeICalLine(b, "BEGIN:STANDARD")
+	writeICalLine(b, "DTSTART:16010101T000000")
+	writeICalLine(b, "TZOFFSETFROM:"+formatUTCOffset(offsetSeconds))
+	writeICalLine(b, "TZOFFSETTO:"+formatUTCOffset(offsetSeconds))
+	writeICalLine(b, "TZNAME:"+name)
+	writeICalLine(b, "END:STANDARD")
+	writeICalLine(b, "END:VTIMEZONE")
+}
+
+// formatUTCOffset renders a UTC offset in seconds as RFC 5545's "+HHMM"/"-HHMM".
+func formatUTCOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+// writeVEvent emits one weekly-recurring VEVENT for ev, identified within the
+// calendar by index.
+func writeVEvent(b *strings.Builder, ev VEvent, tzid string, index int) {
+	byDay := make([]string, len(ev.Days))
+	for i, d := range ev.Days {
+		byDay[i] = strings.ToUpper(d.osmAbbrev())
+	}
+
+	writeICalLine(b, "BEGIN:VEVENT")
+	writeICalLine(b, fmt.Sprintf("UID:opening-hours-%d@gmaps2vcard", index))
+	writeICalLine(b, "SUMMARY:"+ev.Summary)
+	writeICalLine(b, fmt.Sprintf("DTSTART;TZID=%s:%s", tzid, formatICalDateTime(ev.Start)))
+	writeICalLine(b, fmt.Sprintf("DTEND;TZID=%s:%s", tzid, formatICalDateTime(ev.End)))
+	writeICalLine(b, "RRULE:FREQ=WEEKLY;BYDAY="+strings.Join(byDay, ","))
+	writeICalLine(b, "END:VEVENT")
+}
+
+// formatICalDateTime renders t in RFC 5545's local "floating" date-time form
+// (no trailing Z), since its TZID parameter already carries the zone.
+func formatICalDateTime(t time.Time) string {
+	return t.Format("20060102T150405")
+}
+
+// writeICalLine appends s to b terminated by the CRLF RFC 5545 requires.
+func writeICalLine(b *strings.Builder, s string) {
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,107 @@
+package schedule
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-ical"
+)
+
+// icalWeekday maps an RFC 5545 BYDAY code back to the time.Weekday it names.
+var icalWeekday = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// expandVEvent re-derives every occurrence of a weekly-recurring VEVENT
+// within [from, to), the same window shape WeekSchedule.Intervals takes.
+func expandVEvent(t *testing.T, comp *ical.Component, loc *time.Location, from, to time.Time) []Interval {
+	t.Helper()
+
+	tzid := comp.Props.Get("DTSTART").Params.Get("TZID")
+	start, err := time.ParseInLocation("20060102T150405", comp.Props.Get("DTSTART").Value, loc)
+	if err != nil {
+		t.Fatalf("DTSTART %q: %v", comp.Props.Get("DTSTART").Value, err)
+	}
+	end, err := time.ParseInLocation("20060102T150405", comp.Props.Get("DTEND").Value, loc)
+	if err != nil {
+		t.Fatalf("DTEND %q: %v", comp.Props.Get("DTEND").Value, err)
+	}
+	if tzid != loc.String() {
+		t.Fatalf("DTSTART TZID = %q, want %q", tzid, loc.String())
+	}
+	dayOffset := end.Sub(start)
+	startOfDay := start.Sub(time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc))
+
+	var days map[time.Weekday]bool
+	for _, code := range strings.Split(strings.TrimPrefix(comp.Props.Get("RRULE").Value, "FREQ=WEEKLY;BYDAY="), ",") {
+		if days == nil {
+			days = map[time.Weekday]bool{}
+		}
+		days[icalWeekday[code]] = true
+	}
+
+	var intervals []Interval
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !days[day.Weekday()] {
+			continue
+		}
+		s := day.Add(startOfDay)
+		intervals = append(intervals, Interval{Start: s, End: s.Add(dayOffset)})
+	}
+	return intervals
+}
+
+func TestICalendarRoundTrip(t *testing.T) {
+	ws, err := Parse("monday 9:00-17:00 tuesday 9:00-17:00 wednesday 9:00-17:00 thursday 9:00-17:00 friday 9:00-17:00 saturday closed sunday closed", nil, WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	ics := ws.ICalendar("-//gmaps2vcard//Test//EN")
+
+	cal, err := ical.NewDecoder(strings.NewReader(ics)).Decode()
+	if err != nil {
+		t.Fatalf("decoding produced ICS: %v", err)
+	}
+
+	from := time.Date(2026, time.July, 27, 0, 0, 0, 0, time.UTC) // a Monday
+	to := from.AddDate(0, 0, 14)
+
+	var got []Interval
+	for _, comp := range cal.Children {
+		if comp.Name != "VEVENT" {
+			continue
+		}
+		got = append(got, expandVEvent(t, comp, time.UTC, from, to)...)
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i].Start.Before(got[j].Start) })
+
+	want := ws.Intervals(from, to)
+
+	if len(got) != len(want) {
+		t.Fatalf("expanded %d intervals from ICS, want %d\ngot:  %v\nwant: %v", len(got), len(want), got, want)
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Errorf("interval %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestICalendarOmitsClosedDays(t *testing.T) {
+	ws, err := Parse("monday 9:00-17:00 tuesday closed wednesday closed thursday closed friday closed saturday closed sunday closed", nil, WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	events := ws.ToVEvents()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 VEvent for a single open day, got %d", len(events))
+	}
+	if len(events[0].Days) != 1 || events[0].Days[0] != Monday {
+		t.Errorf("expected the VEvent to recur only on Monday, got %v", events[0].Days)
+	}
+}
@@ -2,12 +2,21 @@ package schedule
 
 import (
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// discardLogger is the default used wherever Parse, Format, and friends are
+// called with a nil *slog.Logger: every Debug call becomes a cheap no-op
+// (slog checks the handler's level before formatting), matching the old
+// debug=false behavior without needing an explicit gate at each call site.
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
 // DayOfWeek represents a day (0=Sunday, 6=Saturday for consistency)
 type DayOfWeek int
 
@@ -31,14 +40,34 @@ func (d DayOfWeek) FullName() string {
 	return [...]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}[d]
 }
 
-// TimeRange represents a single time period (e.g., "8:00-13:00")
+// osmAbbrev returns the two-letter day abbreviation the OSM opening_hours
+// grammar uses (Mo, Tu, We, Th, Fr, Sa, Su).
+func (d DayOfWeek) osmAbbrev() string {
+	return [...]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}[d]
+}
+
+// TimeRange is a single open interval within a day, stored as an offset from
+// local midnight rather than an opaque "HH:MM" string so it can drive
+// WeekSchedule's IsOpen/NextChange/Intervals queries. End <= Start means the
+// range spills past midnight into the next day (e.g. 22:00-02:00).
 type TimeRange struct {
-	Start string // "8:00" or "08:00"
-	End   string // "13:00" or "18:00"
+	Start time.Duration
+	End   time.Duration
 }
 
 func (tr TimeRange) String() string {
-	return fmt.Sprintf("%s-%s", tr.Start, tr.End)
+	return fmt.Sprintf("%s-%s", Clock(tr.Start), Clock(tr.End))
+}
+
+// Clock formats a time-of-day Duration as zero-padded 24-hour "HH:MM", the
+// format TimeRange.String, FormatOSM, and schema.org's openingHoursSpecification
+// all render.
+func Clock(d time.Duration) string {
+	d %= 24 * time.Hour
+	if d < 0 {
+		d += 24 * time.Hour
+	}
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int((d%time.Hour)/time.Minute))
 }
 
 // DaySchedule represents all time ranges for a single day
@@ -48,19 +77,50 @@ type DaySchedule struct {
 	Closed bool
 }
 
-// WeekSchedule represents a full week of business hours
+// WeekSchedule represents a full week of business hours.
 type WeekSchedule struct {
 	Days [7]DaySchedule
+
+	// Location is the timezone IsOpen, NextChange, and Intervals interpret
+	// the schedule in. Defaults to time.Local; set via WithLocation.
+	Location *time.Location
+}
+
+// location returns ws.Location, falling back to time.Local for a
+// WeekSchedule built without WithLocation (e.g. via a struct literal).
+func (ws *WeekSchedule) location() *time.Location {
+	if ws.Location != nil {
+		return ws.Location
+	}
+	return time.Local
+}
+
+// Option configures a WeekSchedule at Parse time.
+type Option func(*WeekSchedule)
+
+// WithLocation sets the timezone IsOpen, NextChange, and Intervals interpret
+// the parsed schedule in. Defaults to time.Local if not given.
+func WithLocation(loc *time.Location) Option {
+	return func(ws *WeekSchedule) {
+		ws.Location = loc
+	}
 }
 
-// Parse extracts structured schedule from raw text
-func Parse(rawText string, debug bool) (*WeekSchedule, error) {
-	if debug {
-		log.Printf("[DEBUG] === Schedule Parser Start ===")
-		log.Printf("[DEBUG] Raw input: %q", rawText)
+// Parse extracts a structured schedule from raw text, accepting both
+// 24-hour ("8:00-13:00") and 12-hour ("8:00 am-1:00 pm") time ranges. It
+// returns an error if any day lists overlapping ranges. A nil logger
+// discards debug output; pass one built at slog.LevelDebug to see it.
+func Parse(rawText string, logger *slog.Logger, opts ...Option) (*WeekSchedule, error) {
+	if logger == nil {
+		logger = discardLogger
 	}
+	logger.Debug("=== Schedule Parser Start ===")
+	logger.Debug("raw input", "text", rawText)
 
-	schedule := &WeekSchedule{}
+	schedule := &WeekSchedule{Location: time.Local}
+	for _, opt := range opts {
+		opt(schedule)
+	}
 
 	// Initialize all days
 	for i := 0; i < 7; i++ {
@@ -72,38 +132,32 @@ func Parse(rawText string, debug bool) (*WeekSchedule, error) {
 	}
 
 	// Normalize the input
-	normalized := normalizeText(rawText, debug)
-	if debug {
-		log.Printf("[DEBUG] Normalized: %q", normalized)
-	}
+	normalized := normalizeText(rawText, logger)
+	logger.Debug("normalized", "text", normalized)
 
 	// Extract day-hour pairs
-	parsed := parseScheduleText(normalized, debug)
+	parsed, err := parseScheduleText(normalized, logger)
+	if err != nil {
+		return nil, err
+	}
 
 	// Populate schedule
 	for day, ranges := range parsed {
 		if dayNum, ok := dayNameToNumber(day); ok {
 			schedule.Days[dayNum].Ranges = ranges
 			schedule.Days[dayNum].Closed = len(ranges) == 0
-			if debug {
-				log.Printf("[DEBUG] Set %s (%d): %v (closed=%v)",
-					day, dayNum, ranges, schedule.Days[dayNum].Closed)
-			}
+			logger.Debug("set day", "day", day, "num", dayNum, "ranges", ranges, "closed", schedule.Days[dayNum].Closed)
 		}
 	}
 
-	if debug {
-		log.Printf("[DEBUG] === Schedule Parser Complete ===")
-	}
+	logger.Debug("=== Schedule Parser Complete ===")
 
 	return schedule, nil
 }
 
 // normalizeText cleans and standardizes the input text
-func normalizeText(text string, debug bool) string {
-	if debug {
-		log.Printf("[DEBUG] Normalizing text...")
-	}
+func normalizeText(text string, logger *slog.Logger) string {
+	logger.Debug("normalizing text...")
 
 	// Remove special Unicode characters (en-dash, em-dash → hyphen)
 	text = strings.ReplaceAll(text, "–", "-")
@@ -140,20 +194,22 @@ func normalizeText(text string, debug bool) string {
 	// Also handle "closed" / "cerrado"
 	lower = strings.ReplaceAll(lower, "cerrado", "closed")
 
-	if debug {
-		log.Printf("[DEBUG] After translation: %q", lower)
-	}
+	logger.Debug("after translation", "text", lower)
 
 	return lower
 }
 
-// parseScheduleText extracts day -> time ranges mapping
-func parseScheduleText(text string, debug bool) map[string][]TimeRange {
+// timeRangeRegex matches a pair of times separated by a hyphen, in either
+// 24-hour ("8:00-13:00") or 12-hour ("8:00 am-1:00 pm") form. The input text
+// is already lowercased by normalizeText, so "am"/"pm" are matched lowercase.
+var timeRangeRegex = regexp.MustCompile(`(\d{1,2}:\d{2}\s*(?:am|pm)?)\s*-\s*(\d{1,2}:\d{2}\s*(?:am|pm)?)`)
+
+// parseScheduleText extracts a day -> time ranges mapping, erroring if any
+// day's ranges overlap.
+func parseScheduleText(text string, logger *slog.Logger) (map[string][]TimeRange, error) {
 	result := make(map[string][]TimeRange)
 
-	if debug {
-		log.Printf("[DEBUG] Parsing schedule text...")
-	}
+	logger.Debug("parsing schedule text...")
 
 	// Pattern: day_name followed by time ranges or "closed"
 	// e.g., "monday 8:00-13:00 15:00-18:00" or "sunday closed"
@@ -164,9 +220,7 @@ func parseScheduleText(text string, debug bool) map[string][]TimeRange {
 		// Find the day in the text
 		dayIdx := strings.Index(text, day)
 		if dayIdx == -1 {
-			if debug {
-				log.Printf("[DEBUG] Day %s not found in text", day)
-			}
+			logger.Debug("day not found in text", "day", day)
 			continue
 		}
 
@@ -185,62 +239,102 @@ func parseScheduleText(text string, debug bool) map[string][]TimeRange {
 
 		dayContent := strings.TrimSpace(afterDay[:nextDayIdx])
 
-		if debug {
-			log.Printf("[DEBUG] %s content: %q", day, dayContent)
-		}
+		logger.Debug("day content", "day", day, "content", dayContent)
 
 		// Check if closed
 		if strings.Contains(dayContent, "closed") {
 			result[day] = []TimeRange{}
-			if debug {
-				log.Printf("[DEBUG] %s: CLOSED", day)
-			}
+			logger.Debug("closed", "day", day)
 			continue
 		}
 
-		// Extract time ranges (format: HH:MM-HH:MM)
-		timeRegex := regexp.MustCompile(`(\d{1,2}:\d{2})\s*-\s*(\d{1,2}:\d{2})`)
-		matches := timeRegex.FindAllStringSubmatch(dayContent, -1)
+		// Extract time ranges (24-hour or 12-hour)
+		matches := timeRangeRegex.FindAllStringSubmatch(dayContent, -1)
 
 		if len(matches) > 0 {
 			ranges := make([]TimeRange, 0, len(matches))
 			for _, match := range matches {
-				tr := TimeRange{
-					Start: normalizeTime(match[1]),
-					End:   normalizeTime(match[2]),
+				start, err := parseTimeOfDay(match[1])
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", day, err)
 				}
-				ranges = append(ranges, tr)
-				if debug {
-					log.Printf("[DEBUG] %s: found range %s", day, tr)
+				end, err := parseTimeOfDay(match[2])
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", day, err)
 				}
+				tr := TimeRange{Start: start, End: end}
+				ranges = append(ranges, tr)
+				logger.Debug("found range", "day", day, "range", tr.String())
+			}
+			if err := validateNoOverlap(ranges); err != nil {
+				return nil, fmt.Errorf("%s: %w", day, err)
 			}
 			result[day] = ranges
 		} else {
-			if debug {
-				log.Printf("[DEBUG] %s: no time ranges found", day)
-			}
+			logger.Debug("no time ranges found", "day", day)
 		}
 	}
 
-	return result
+	return result, nil
 }
 
-// normalizeTime ensures consistent time format (HH:MM)
-func normalizeTime(t string) string {
-	parts := strings.Split(t, ":")
+// parseTimeOfDay parses a "HH:MM", "HH:MM am", or "HH:MM pm" time-of-day
+// string into its offset from midnight.
+func parseTimeOfDay(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+
+	suffix := ""
+	if strings.HasSuffix(raw, "am") || strings.HasSuffix(raw, "pm") {
+		suffix = raw[len(raw)-2:]
+		raw = strings.TrimSpace(raw[:len(raw)-2])
+	}
+
+	parts := strings.Split(raw, ":")
 	if len(parts) != 2 {
-		return t
+		return 0, fmt.Errorf("invalid time %q", raw)
+	}
+
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid hour in %q: %w", raw, err)
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid minute in %q: %w", raw, err)
 	}
 
-	hour := parts[0]
-	minute := parts[1]
+	switch suffix {
+	case "am":
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour != 12 {
+			hour += 12
+		}
+	}
+
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, nil
+}
 
-	// Pad hour to 2 digits
-	if len(hour) == 1 {
-		hour = "0" + hour
+// validateNoOverlap reports an error if any two ranges in a single day
+// overlap. Ranges that wrap past midnight (End <= Start) are excluded from
+// the check, since comparing them needs the following day's ranges too.
+func validateNoOverlap(ranges []TimeRange) error {
+	sorted := make([]TimeRange, 0, len(ranges))
+	for _, r := range ranges {
+		if r.End > r.Start {
+			sorted = append(sorted, r)
+		}
 	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
 
-	return hour + ":" + minute
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Start < sorted[i-1].End {
+			return fmt.Errorf("overlapping time ranges: %s and %s", sorted[i-1], sorted[i])
+		}
+	}
+	return nil
 }
 
 // dayNameToNumber converts English day name to DayOfWeek
@@ -260,31 +354,195 @@ func dayNameToNumber(name string) (DayOfWeek, bool) {
 	return day, ok
 }
 
-// Format produces clean, human-readable output
-func (ws *WeekSchedule) Format(debug bool) string {
-	if debug {
-		log.Printf("[DEBUG] === Formatting Schedule ===")
+// Format produces clean, human-readable output. A nil logger discards debug
+// output; pass one built at slog.LevelDebug to see it.
+func (ws *WeekSchedule) Format(logger *slog.Logger) string {
+	if logger == nil {
+		logger = discardLogger
 	}
+	logger.Debug("=== Formatting Schedule ===")
 
 	// Group consecutive days with identical schedules
-	groups := ws.groupConsecutiveDays(debug)
+	groups := ws.groupConsecutiveDays(logger)
 
 	parts := make([]string, 0, len(groups))
 	for _, group := range groups {
-		formatted := formatGroup(group, debug)
+		formatted := formatGroup(group)
 		parts = append(parts, formatted)
 	}
 
 	result := strings.Join(parts, "; ")
 
-	if debug {
-		log.Printf("[DEBUG] Final formatted output: %q", result)
-		log.Printf("[DEBUG] === Formatting Complete ===")
-	}
+	logger.Debug("final formatted output", "result", result)
+	logger.Debug("=== Formatting Complete ===")
 
 	return result
 }
 
+// FormatOSM serializes the week into the OpenStreetMap opening_hours
+// grammar, e.g. "Mo-Fr 08:00-13:00,15:00-18:00; Sa,Su off". Consecutive days
+// with identical ranges collapse into a "Mo-Fr"-style range; days that don't
+// fit a range (e.g. Sa and Su sharing hours but not adjacent to the Mo-Fr
+// group) are left as-is by groupConsecutiveDays, which only ever merges
+// adjacent days.
+func (ws *WeekSchedule) FormatOSM() string {
+	groups := ws.groupConsecutiveDays(discardLogger)
+
+	parts := make([]string, 0, len(groups))
+	for _, group := range groups {
+		parts = append(parts, formatGroupOSM(group))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// Interval is a concrete, timezone-anchored open period returned by
+// Intervals, as opposed to TimeRange which is a recurring offset-from-midnight
+// template.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// IsOpen reports whether ws is open at t. t is converted into ws's Location
+// before being checked against the day's ranges, including any range
+// spilling over from the previous day (e.g. a Friday "22:00-02:00" range
+// covers early Saturday morning).
+func (ws *WeekSchedule) IsOpen(t time.Time) bool {
+	t = t.In(ws.location())
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+
+	today := ws.Days[int(t.Weekday())]
+	for _, r := range today.Ranges {
+		if r.End > r.Start {
+			if offset >= r.Start && offset < r.End {
+				return true
+			}
+			continue
+		}
+		// Wraps past midnight: open from Start until 24:00 today.
+		if offset >= r.Start {
+			return true
+		}
+	}
+
+	yesterday := ws.Days[int(t.AddDate(0, 0, -1).Weekday())]
+	for _, r := range yesterday.Ranges {
+		if r.End <= r.Start && offset < r.End {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextChange returns the next time after t at which ws transitions from open
+// to closed or closed to open, scanning up to 7 days ahead. ok is false if no
+// change is found in that window (e.g. ws is open or closed every day).
+func (ws *WeekSchedule) NextChange(t time.Time) (time.Time, bool) {
+	t = t.In(ws.location())
+	start := t
+	state := ws.IsOpen(t)
+
+	// Step through every boundary (range start/end) over the next 7 days,
+	// in order, until the open/closed state differs from t's.
+	for dayOffset := 0; dayOffset <= 7; dayOffset++ {
+		day := t.AddDate(0, 0, dayOffset)
+		midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+		sched := ws.Days[int(day.Weekday())]
+
+		boundaries := make([]time.Duration, 0, len(sched.Ranges)*2)
+		for _, r := range sched.Ranges {
+			end := r.End
+			if end <= r.Start {
+				end += 24 * time.Hour // wraps into the next day, same as Intervals
+			}
+			boundaries = append(boundaries, r.Start, end)
+		}
+		sort.Slice(boundaries, func(i, j int) bool { return boundaries[i] < boundaries[j] })
+
+		for _, b := range boundaries {
+			candidate := midnight.Add(b)
+			if !candidate.After(start) {
+				continue
+			}
+			if ws.IsOpen(candidate) != state {
+				return candidate, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// Intervals returns every open period that overlaps [from, to), clipped to
+// that window. from and to are interpreted in ws's Location.
+func (ws *WeekSchedule) Intervals(from, to time.Time) []Interval {
+	from = from.In(ws.location())
+	to = to.In(ws.location())
+
+	var intervals []Interval
+
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location()); day.Before(to); day = day.AddDate(0, 0, 1) {
+		sched := ws.Days[int(day.Weekday())]
+		for _, r := range sched.Ranges {
+			end := r.End
+			if end <= r.Start {
+				end += 24 * time.Hour // wraps into the next day
+			}
+
+			start := day.Add(r.Start)
+			finish := day.Add(end)
+
+			if finish.Before(from) || !start.Before(to) {
+				continue
+			}
+			if start.Before(from) {
+				start = from
+			}
+			if finish.After(to) {
+				finish = to
+			}
+			intervals = append(intervals, Interval{Start: start, End: finish})
+		}
+	}
+
+	return intervals
+}
+
+// formatGroupOSM renders a single dayGroup in OSM opening_hours syntax. OSM
+// convention uses a dash range (Mo-Fr) for three or more consecutive days
+// but a comma list (Sa,Su) for just two, so a 2-day group is special-cased.
+func formatGroupOSM(g dayGroup) string {
+	var dayPart string
+	switch {
+	case g.StartDay == g.EndDay:
+		dayPart = g.StartDay.osmAbbrev()
+	case weekOrderIndex(g.EndDay)-weekOrderIndex(g.StartDay) == 1:
+		dayPart = fmt.Sprintf("%s,%s", g.StartDay.osmAbbrev(), g.EndDay.osmAbbrev())
+	default:
+		dayPart = fmt.Sprintf("%s-%s", g.StartDay.osmAbbrev(), g.EndDay.osmAbbrev())
+	}
+
+	if g.Closed || len(g.Ranges) == 0 {
+		return fmt.Sprintf("%s off", dayPart)
+	}
+
+	ranges := make([]TimeRange, len(g.Ranges))
+	copy(ranges, g.Ranges)
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+
+	rangeParts := make([]string, len(ranges))
+	for i, r := range ranges {
+		rangeParts[i] = r.String()
+	}
+
+	return fmt.Sprintf("%s %s", dayPart, strings.Join(rangeParts, ","))
+}
+
 // dayGroup represents consecutive days with the same schedule
 type dayGroup struct {
 	StartDay DayOfWeek
@@ -293,8 +551,19 @@ type dayGroup struct {
 	Closed   bool
 }
 
-// groupConsecutiveDays finds days with identical schedules
-func (ws *WeekSchedule) groupConsecutiveDays(debug bool) []dayGroup {
+// weekOrderIndex returns d's position in the Monday-first business-week
+// ordering groupConsecutiveDays walks, so callers can tell how many days
+// apart two DayOfWeek values are.
+func weekOrderIndex(d DayOfWeek) int {
+	return [...]int{6, 0, 1, 2, 3, 4, 5}[d] // Sunday=index 6, Monday=index 0, ...
+}
+
+// groupConsecutiveDays finds days with identical schedules. A nil logger
+// discards debug output.
+func (ws *WeekSchedule) groupConsecutiveDays(logger *slog.Logger) []dayGroup {
+	if logger == nil {
+		logger = discardLogger
+	}
 	groups := []dayGroup{}
 
 	// Start with Monday for business-friendly ordering
@@ -327,10 +596,7 @@ func (ws *WeekSchedule) groupConsecutiveDays(debug bool) []dayGroup {
 
 		groups = append(groups, group)
 
-		if debug {
-			log.Printf("[DEBUG] Group: %s-%s, Closed=%v, Ranges=%v",
-				group.StartDay, group.EndDay, group.Closed, group.Ranges)
-		}
+		logger.Debug("group", "start", group.StartDay, "end", group.EndDay, "closed", group.Closed, "ranges", group.Ranges)
 
 		i = endIdx + 1
 	}
@@ -358,7 +624,7 @@ func schedulesEqual(a, b DaySchedule) bool {
 }
 
 // formatGroup formats a day group
-func formatGroup(g dayGroup, debug bool) string {
+func formatGroup(g dayGroup) string {
 	// Day range
 	var dayPart string
 	if g.StartDay == g.EndDay {
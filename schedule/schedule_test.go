@@ -2,18 +2,19 @@ package schedule
 
 import (
 	"testing"
+	"time"
 )
 
 func TestParseSpanishSchedule(t *testing.T) {
 	// Your example input
 	input := "jueves 8:00–13:00 15:00–18:00  viernes 8:00–13:00 15:00–18:00  sábado  domingo  lunes 8:00–13:00 15:00–18:00  martes 8:00–13:00 15:00–18:00  miércoles 8:00–13:00 15:00–18:00"
 
-	schedule, err := Parse(input, true) // debug=true
+	schedule, err := Parse(input, nil)
 	if err != nil {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	formatted := schedule.Format(true) // debug=true
+	formatted := schedule.Format(nil)
 	t.Logf("Formatted output: %s", formatted)
 
 	// Expected: "Mon-Fri 08:00-13:00, 15:00-18:00; Sat Closed; Sun Closed"
@@ -28,10 +29,10 @@ func TestParseSpanishSchedule(t *testing.T) {
 		t.Errorf("Monday should have 2 time ranges, got %d", len(monday.Ranges))
 	}
 	if len(monday.Ranges) >= 2 {
-		if monday.Ranges[0].Start != "08:00" || monday.Ranges[0].End != "13:00" {
+		if monday.Ranges[0].Start != 8*time.Hour || monday.Ranges[0].End != 13*time.Hour {
 			t.Errorf("Monday first range incorrect: %v", monday.Ranges[0])
 		}
-		if monday.Ranges[1].Start != "15:00" || monday.Ranges[1].End != "18:00" {
+		if monday.Ranges[1].Start != 15*time.Hour || monday.Ranges[1].End != 18*time.Hour {
 			t.Errorf("Monday second range incorrect: %v", monday.Ranges[1])
 		}
 	}
@@ -52,12 +53,12 @@ func TestParseSpanishSchedule(t *testing.T) {
 func TestParseEnglishSchedule(t *testing.T) {
 	input := "Monday 9:00-17:00 Tuesday 9:00-17:00 Wednesday closed Thursday 9:00-17:00 Friday 9:00-17:00 Saturday closed Sunday closed"
 
-	schedule, err := Parse(input, true)
+	schedule, err := Parse(input, nil)
 	if err != nil {
 		t.Fatalf("Parse failed: %v", err)
 	}
 
-	formatted := schedule.Format(true)
+	formatted := schedule.Format(nil)
 	t.Logf("Formatted: %s", formatted)
 
 	// Check Wednesday is closed
@@ -78,7 +79,7 @@ func TestFormatConsecutiveDays(t *testing.T) {
 	schedule := &WeekSchedule{}
 
 	standardRanges := []TimeRange{
-		{Start: "09:00", End: "17:00"},
+		{Start: 9 * time.Hour, End: 17 * time.Hour},
 	}
 
 	// Mon-Fri: 9-5
@@ -94,7 +95,7 @@ func TestFormatConsecutiveDays(t *testing.T) {
 	schedule.Days[Saturday] = DaySchedule{Day: Saturday, Closed: true}
 	schedule.Days[Sunday] = DaySchedule{Day: Sunday, Closed: true}
 
-	formatted := schedule.Format(true)
+	formatted := schedule.Format(nil)
 	t.Logf("Formatted: %s", formatted)
 
 	// Should contain "Mon-Fri" as a range
@@ -106,3 +107,91 @@ func TestFormatConsecutiveDays(t *testing.T) {
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && (s[:len(substr)] == substr || contains(s[1:], substr)))
 }
+
+func TestParse12HourSchedule(t *testing.T) {
+	input := "monday 8:00 am-1:00 pm 3:00 pm-6:00 pm tuesday closed"
+
+	schedule, err := Parse(input, nil)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	monday := schedule.Days[Monday]
+	if len(monday.Ranges) != 2 {
+		t.Fatalf("Monday should have 2 time ranges, got %d", len(monday.Ranges))
+	}
+	if monday.Ranges[0].Start != 8*time.Hour || monday.Ranges[0].End != 13*time.Hour {
+		t.Errorf("Monday first range incorrect: %v", monday.Ranges[0])
+	}
+	if monday.Ranges[1].Start != 15*time.Hour || monday.Ranges[1].End != 18*time.Hour {
+		t.Errorf("Monday second range incorrect: %v", monday.Ranges[1])
+	}
+}
+
+func TestParseRejectsOverlap(t *testing.T) {
+	input := "monday 8:00-13:00 12:00-18:00"
+
+	if _, err := Parse(input, nil); err == nil {
+		t.Errorf("expected an error for overlapping ranges, got nil")
+	}
+}
+
+func TestWeekScheduleIsOpen(t *testing.T) {
+	schedule, err := Parse("monday 9:00-17:00 tuesday closed", nil, WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	open := time.Date(2026, time.July, 27, 12, 0, 0, 0, time.UTC) // Monday
+	if !schedule.IsOpen(open) {
+		t.Errorf("expected open at %v", open)
+	}
+
+	closed := time.Date(2026, time.July, 28, 12, 0, 0, 0, time.UTC) // Tuesday
+	if schedule.IsOpen(closed) {
+		t.Errorf("expected closed at %v", closed)
+	}
+
+	next, ok := schedule.NextChange(open)
+	if !ok {
+		t.Fatalf("expected a next change after %v", open)
+	}
+	want := time.Date(2026, time.July, 27, 17, 0, 0, 0, time.UTC)
+	if !next.Equal(want) {
+		t.Errorf("NextChange = %v, want %v", next, want)
+	}
+
+	from := time.Date(2026, time.July, 27, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+	intervals := schedule.Intervals(from, to)
+	if len(intervals) != 1 {
+		t.Fatalf("expected 1 interval, got %d", len(intervals))
+	}
+	if !intervals[0].Start.Equal(time.Date(2026, time.July, 27, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("interval start incorrect: %v", intervals[0].Start)
+	}
+	if !intervals[0].End.Equal(want) {
+		t.Errorf("interval end incorrect: %v", intervals[0].End)
+	}
+}
+
+func TestNextChangeOvernightWrap(t *testing.T) {
+	schedule, err := Parse("monday 22:00-02:00 tuesday closed wednesday closed thursday closed friday closed saturday closed sunday closed", nil, WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	at := time.Date(2026, time.July, 27, 23, 0, 0, 0, time.UTC) // Monday 23:00, inside the 22:00-02:00 range
+	if !schedule.IsOpen(at) {
+		t.Fatalf("expected open at %v", at)
+	}
+
+	next, ok := schedule.NextChange(at)
+	if !ok {
+		t.Fatalf("expected a next change after %v", at)
+	}
+	want := time.Date(2026, time.July, 28, 2, 0, 0, 0, time.UTC) // Tuesday 02:00, not a week later
+	if !next.Equal(want) {
+		t.Errorf("NextChange = %v, want %v", next, want)
+	}
+}
@@ -0,0 +1,78 @@
+package scraper
+
+import (
+	"fmt"
+	"sync"
+
+	"gmaps2vcard/browser"
+)
+
+// Result holds the outcome of extracting one URL submitted to a Pool.
+type Result struct {
+	URL      string
+	Business *BusinessData
+	Err      error
+}
+
+// Pool runs many Extract calls concurrently against a single shared Browser,
+// so bulk extraction pays Chrome's startup cost once instead of once per URL
+// and stays bounded to a fixed number of concurrent tabs. Create one with
+// NewPool, Submit URLs as they become available, and Close it once every
+// returned channel has been drained.
+type Pool struct {
+	browser *browser.Browser
+	scraper *Scraper
+	config  *Config
+	wg      sync.WaitGroup
+}
+
+// NewPool launches a shared browser capped at size concurrent tabs and
+// returns a Pool ready for Submit. cfg is used for every extraction; nil
+// falls back to DefaultConfig().
+func NewPool(size int, cfg *Config) (*Pool, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	if size < 1 {
+		size = 1
+	}
+
+	opts := browser.DefaultOptions()
+	opts.MaxTabs = size
+	opts.ProxyURL = cfg.Transport.NextProxy()
+
+	br, err := browser.NewBrowser(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start browser: %w", err)
+	}
+
+	return &Pool{
+		browser: br,
+		scraper: NewScraper(br),
+		config:  cfg,
+	}, nil
+}
+
+// Submit queues url for extraction and returns a channel that receives
+// exactly one Result, then closes. Submit never blocks; the pool's Browser
+// bounds how many extractions actually run at once.
+func (p *Pool) Submit(url string) <-chan Result {
+	out := make(chan Result, 1)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		business, err := p.scraper.Extract(url, p.config)
+		out <- Result{URL: url, Business: business, Err: err}
+		close(out)
+	}()
+
+	return out
+}
+
+// Close waits for every submitted extraction to finish, then shuts down the
+// pool's browser. The Pool must not be used again afterward.
+func (p *Pool) Close() {
+	p.wg.Wait()
+	p.browser.Close()
+}
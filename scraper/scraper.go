@@ -11,6 +11,11 @@ import (
 	"time"
 
 	"github.com/chromedp/chromedp"
+
+	"gmaps2vcard/browser"
+	"gmaps2vcard/selectors"
+	"gmaps2vcard/transport"
+	"gmaps2vcard/useragent"
 )
 
 // BusinessData contains all extracted business information
@@ -29,29 +34,88 @@ type BusinessData struct {
 type Config struct {
 	Timeout  time.Duration
 	WaitTime time.Duration
+
+	// Backend resolves business data for a normalized maps/place URL.
+	// Defaults to scraping the rendered DOM via chromedp. Inject an
+	// alternative (e.g. the places package's API-backed Backend) to bypass
+	// scraping; chromedp is still used to resolve search pages to a
+	// maps/place URL, and is used as a fallback if Backend.Extract fails.
+	Backend Backend
+
+	// Selectors lists the candidate locators tried, in order, for each
+	// field when scraping the rendered DOM. Defaults to selectors.Default()
+	// when nil. Only consulted by the chromedp backend.
+	Selectors *selectors.Profile
+
+	// MaxResults caps how many place URLs ExtractAll collects from a search
+	// page's results feed before extracting. 0 means no cap.
+	MaxResults int
+
+	// Transport configures proxy rotation and block/CAPTCHA retries for the
+	// redirect-following HTTP request. Nil means no proxies and a single
+	// direct attempt.
+	Transport *transport.Config
 }
 
 // DefaultConfig returns sensible defaults
 func DefaultConfig() *Config {
 	return &Config{
-		Timeout:  45 * time.Second,
-		WaitTime: 3 * time.Second,
+		Timeout:   45 * time.Second,
+		WaitTime:  3 * time.Second,
+		Selectors: selectors.Default(),
+	}
+}
+
+// Backend resolves business data for a normalized Google Maps place URL.
+// Implementations are free to scrape the page, call an API, or anything
+// else; Scraper.Extract falls back to the default chromedp backend if
+// Backend.Extract returns an error.
+type Backend interface {
+	Extract(ctx context.Context, pageURL string) (*BusinessData, error)
+}
+
+// chromedpBackend is the default Backend: it scrapes the rendered Maps DOM
+// in the caller's existing chromedp tab.
+type chromedpBackend struct {
+	config *Config
+}
+
+func (b *chromedpBackend) Extract(ctx context.Context, pageURL string) (*BusinessData, error) {
+	business := &BusinessData{}
+	if err := extractBusinessData(ctx, pageURL, business, b.config); err != nil {
+		return nil, err
 	}
+	return business, nil
+}
+
+// Scraper extracts business data using a shared Browser, so callers can run
+// many extractions without paying Chrome's startup cost on every URL.
+type Scraper struct {
+	browser *browser.Browser
+}
+
+// NewScraper creates a Scraper that runs extractions as tabs on br.
+func NewScraper(br *browser.Browser) *Scraper {
+	return &Scraper{browser: br}
 }
 
 // Extract extracts all business data from ANY Google Maps URL in ONE chromedp session
 // Handles URL normalization, search page extraction, and business data scraping
-func Extract(inputURL string, config *Config) (*BusinessData, error) {
+func (s *Scraper) Extract(inputURL string, config *Config) (*BusinessData, error) {
 	if config == nil {
 		config = DefaultConfig()
 	}
 
+	if err := ValidateGoogleMapsURL(inputURL); err != nil {
+		return nil, err
+	}
+
 	business := &BusinessData{}
 	log.Printf("[Scraper] Starting extraction from: %.80s...", inputURL)
 
 	// Step 1: Follow HTTP redirects (no chromedp needed)
 	log.Printf("[Scraper] Following redirects...")
-	finalURL, err := followRedirects(inputURL)
+	finalURL, err := followRedirects(inputURL, config.Transport)
 	if err != nil {
 		return nil, fmt.Errorf("failed to follow redirects: %w", err)
 	}
@@ -70,23 +134,24 @@ func Extract(inputURL string, config *Config) (*BusinessData, error) {
 		business.Longitude = matches[2]
 	}
 
-	// Step 3: Set up chromedp - ONE session for EVERYTHING
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.UserAgent("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
-		chromedp.Flag("disable-blink-features", "AutomationControlled"),
-		chromedp.Flag("exclude-switches", "enable-automation"),
-		chromedp.Flag("headless", true),
-		chromedp.WindowSize(1920, 1080),
-	)
-
-	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), opts...)
-	defer allocCancel()
+	// Step 3: Open a tab on the shared browser - ONE session for EVERYTHING.
+	// If no Browser was injected, fall back to starting one just for this
+	// call so Extract keeps working for simple, one-shot use.
+	br := s.browser
+	if br == nil {
+		var err error
+		br, err = browser.NewBrowser(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start browser: %w", err)
+		}
+		defer br.Close()
+	}
 
-	ctx, ctxCancel := chromedp.NewContext(allocCtx)
-	defer ctxCancel()
+	deadlineCtx, deadlineCancel := context.WithTimeout(br.Context(), config.Timeout)
+	defer deadlineCancel()
 
-	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, config.Timeout)
-	defer timeoutCancel()
+	timeoutCtx, tabCancel := br.NewTab(deadlineCtx)
+	defer tabCancel()
 
 	// Step 4: Handle URL type and navigate
 	var mapsPlaceURL string
@@ -110,37 +175,47 @@ func Extract(inputURL string, config *Config) (*BusinessData, error) {
 		return nil, fmt.Errorf("unknown Google Maps URL type: %s", u.Path)
 	}
 
-	// Step 5: Navigate to maps/place URL and extract ALL business data (same chromedp session)
-	log.Printf("[Scraper] Extracting business data...")
-	err = extractBusinessData(timeoutCtx, mapsPlaceURL, business, config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract business data: %w", err)
+	// Step 5: Resolve business data through the configured backend,
+	// falling back to chromedp scraping if it fails (e.g. API error or
+	// quota exhaustion).
+	backend := config.Backend
+	if backend == nil {
+		backend = &chromedpBackend{config: config}
 	}
 
-	log.Printf("[Scraper] ✓ Extraction complete")
-	return business, nil
-}
-
-// followRedirects follows all HTTP redirects and returns the final URL
-func followRedirects(inputURL string) (string, error) {
-	client := &http.Client{
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return nil // Allow all redirects
-		},
-		Timeout: 10 * time.Second,
+	log.Printf("[Scraper] Extracting business data...")
+	resolved, err := backend.Extract(timeoutCtx, mapsPlaceURL)
+	if err != nil {
+		if _, alreadyChromedp := backend.(*chromedpBackend); alreadyChromedp {
+			return nil, fmt.Errorf("failed to extract business data: %w", err)
+		}
+		log.Printf("[Scraper] ⚠ Backend extraction failed (%v), falling back to chromedp scraping", err)
+		resolved, err = (&chromedpBackend{config: config}).Extract(timeoutCtx, mapsPlaceURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract business data: %w", err)
+		}
 	}
 
-	req, err := http.NewRequest("GET", inputURL, nil)
-	if err != nil {
-		return "", err
+	// Keep the coordinates parsed from the URL if the backend didn't supply its own.
+	if resolved.Latitude == "" && resolved.Longitude == "" {
+		resolved.Latitude = business.Latitude
+		resolved.Longitude = business.Longitude
 	}
 
-	// Legitimate browser headers
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	log.Printf("[Scraper] ✓ Extraction complete")
+	return resolved, nil
+}
 
-	resp, err := client.Do(req)
+// followRedirects follows all HTTP redirects and returns the final URL. If
+// transportConfig has proxies configured, a blocked response (403/429 or a
+// /sorry/ CAPTCHA interstitial) is retried against the next proxy and a
+// freshly-picked User-Agent, up to transportConfig.MaxRetries times.
+func followRedirects(inputURL string, transportConfig *transport.Config) (string, error) {
+	resp, err := transport.Get(inputURL, transportConfig, func(req *http.Request) {
+		useragent.Pick().Apply(req)
+		req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8")
+		req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	})
 	if err != nil {
 		return "", err
 	}
@@ -213,31 +288,38 @@ func extractMapsPlaceFromSearch(ctx context.Context, searchURL string, config *C
 
 // extractBusinessData extracts all business data from a maps/place page (in existing chromedp session)
 func extractBusinessData(ctx context.Context, pageURL string, business *BusinessData, config *Config) error {
-	var name, address, phone, website string
+	profile := config.Selectors
+	if profile == nil {
+		profile = selectors.Default()
+	}
 
 	err := chromedp.Run(ctx,
 		chromedp.Navigate(pageURL),
 		chromedp.WaitReady("body"),
 		chromedp.Sleep(config.WaitTime),
-
-		// Extract basic business info
-		chromedp.Text(`h1`, &name, chromedp.NodeVisible, chromedp.ByQuery),
-		chromedp.AttributeValue(`button[data-item-id="address"]`, "aria-label", &address, nil, chromedp.ByQuery),
-		chromedp.AttributeValue(`button[data-item-id*="phone"]`, "aria-label", &phone, nil, chromedp.ByQuery),
-		chromedp.AttributeValue(`a[data-item-id="authority"]`, "href", &website, nil, chromedp.ByQuery),
 	)
+	if err != nil {
+		return fmt.Errorf("failed to load page: %w", err)
+	}
 
+	name, err := tryText(ctx, profile.Name)
 	if err != nil {
 		return fmt.Errorf("failed to extract basic data: %w", err)
 	}
-
 	business.Name = name
-	business.Address = cleanAriaLabel(address)
-	business.Phone = cleanAriaLabel(phone)
-	business.Website = website
+
+	if address, err := tryAttribute(ctx, profile.Address, "aria-label"); err == nil {
+		business.Address = cleanAriaLabel(address)
+	}
+	if phone, err := tryAttribute(ctx, profile.Phone, "aria-label"); err == nil {
+		business.Phone = cleanAriaLabel(phone)
+	}
+	if website, err := tryAttribute(ctx, profile.Website, "href"); err == nil {
+		business.Website = website
+	}
 
 	// Extract image URL FIRST (before clicking anything that might open modals)
-	photoURL, err := extractImageURL(ctx)
+	photoURL, err := extractImageURL(ctx, profile.Image)
 	if err != nil {
 		log.Printf("[Scraper] ⚠ Image extraction failed: %v", err)
 	} else {
@@ -245,7 +327,7 @@ func extractBusinessData(ctx context.Context, pageURL string, business *Business
 	}
 
 	// Extract hours (click to expand, then scrape)
-	hours, err := extractHours(ctx, config)
+	hours, err := extractHours(ctx, config, profile.Hours)
 	if err != nil {
 		log.Printf("[Scraper] ⚠ Hours extraction failed: %v", err)
 	} else {
@@ -255,21 +337,66 @@ func extractBusinessData(ctx context.Context, pageURL string, business *Business
 	return nil
 }
 
+// tryAttribute runs each selector in order until one yields a non-empty
+// value for attr, returning the first match.
+func tryAttribute(ctx context.Context, candidates []selectors.Selector, attr string) (string, error) {
+	for _, sel := range candidates {
+		var value string
+		var err error
+		if sel.Kind == selectors.XPath {
+			err = chromedp.Run(ctx, chromedp.AttributeValue(sel.Query, attr, &value, nil, chromedp.BySearch))
+		} else {
+			err = chromedp.Run(ctx, chromedp.AttributeValue(sel.Query, attr, &value, nil, chromedp.ByQuery))
+		}
+		if err == nil && value != "" {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("no selector matched")
+}
+
+// tryText runs each selector in order until one yields non-empty visible
+// text, returning the first match.
+func tryText(ctx context.Context, candidates []selectors.Selector) (string, error) {
+	for _, sel := range candidates {
+		var value string
+		var err error
+		if sel.Kind == selectors.XPath {
+			err = chromedp.Run(ctx, chromedp.Text(sel.Query, &value, chromedp.NodeVisible, chromedp.BySearch))
+		} else {
+			err = chromedp.Run(ctx, chromedp.Text(sel.Query, &value, chromedp.NodeVisible, chromedp.ByQuery))
+		}
+		if err == nil && value != "" {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("no selector matched")
+}
+
 // extractHours clicks the hours button and extracts the full schedule text
-func extractHours(ctx context.Context, config *Config) (string, error) {
+func extractHours(ctx context.Context, config *Config, candidates []selectors.Selector) (string, error) {
 	// Click hours button to expand full schedule
-	err := chromedp.Run(ctx,
-		chromedp.Click(`button[data-item-id="oh"]`, chromedp.ByQuery),
-		chromedp.Sleep(1*time.Second),
-	)
-
-	if err != nil {
-		return "", fmt.Errorf("failed to click hours button: %w", err)
+	clicked := false
+	for _, sel := range candidates {
+		var err error
+		if sel.Kind == selectors.XPath {
+			err = chromedp.Run(ctx, chromedp.Click(sel.Query, chromedp.BySearch))
+		} else {
+			err = chromedp.Run(ctx, chromedp.Click(sel.Query, chromedp.ByQuery))
+		}
+		if err == nil {
+			clicked = true
+			break
+		}
 	}
+	if !clicked {
+		return "", fmt.Errorf("failed to click hours button: no selector matched")
+	}
+	chromedp.Run(ctx, chromedp.Sleep(1*time.Second))
 
 	// Extract all body text (schedule appears in the modal/panel)
 	var bodyText string
-	err = chromedp.Run(ctx,
+	err := chromedp.Run(ctx,
 		chromedp.Text("body", &bodyText, chromedp.ByQuery),
 	)
 
@@ -305,23 +432,17 @@ func extractHours(ctx context.Context, config *Config) (string, error) {
 }
 
 // extractImageURL tries multiple selectors to find the business image
-func extractImageURL(ctx context.Context) (string, error) {
-	selectors := []string{
-		`button[jsaction*="pane.heroHeaderImage"] img`,
-		`div.ZKCDEc img`,
-		`img[src*="googleusercontent.com"]`,
-		`img[src*="gstatic.com/images"]`,
-		`button.aoRNLd img`,
-		`div[role="img"]`,
-	}
-
+func extractImageURL(ctx context.Context, candidates []selectors.Selector) (string, error) {
 	var imageURL string
-	for _, selector := range selectors {
+	for _, sel := range candidates {
 		// Use a short timeout for each selector (don't waste time on non-matching selectors)
 		selectorCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
-		err := chromedp.Run(selectorCtx,
-			chromedp.AttributeValue(selector, "src", &imageURL, nil, chromedp.ByQuery),
-		)
+		var err error
+		if sel.Kind == selectors.XPath {
+			err = chromedp.Run(selectorCtx, chromedp.AttributeValue(sel.Query, "src", &imageURL, nil, chromedp.BySearch))
+		} else {
+			err = chromedp.Run(selectorCtx, chromedp.AttributeValue(sel.Query, "src", &imageURL, nil, chromedp.ByQuery))
+		}
 		cancel()
 
 		if err == nil && imageURL != "" {
@@ -0,0 +1,174 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+
+	"gmaps2vcard/browser"
+)
+
+// resultsFeedSelector is the left-sidebar results list a Google Maps
+// /maps/search/ page renders its result cards into.
+const resultsFeedSelector = `div[role="feed"]`
+
+// ExtractAll extracts every business from a Google Maps search: a
+// /maps/place/ URL is treated as a single-result search and extracted as
+// usual, while a /maps/search/ URL (or a web-search page with a local pack)
+// has its results feed scrolled to load every card, deduplicated by place
+// URL, and extracted concurrently on the same shared Browser. A cfg.MaxResults
+// > 0 stops collection once that many place URLs have been found.
+func (s *Scraper) ExtractAll(inputURL string, cfg *Config) ([]*BusinessData, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+
+	if err := ValidateGoogleMapsURL(inputURL); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[Scraper] Starting search extraction from: %.80s...", inputURL)
+
+	finalURL, err := followRedirects(inputURL, cfg.Transport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to follow redirects: %w", err)
+	}
+
+	u, err := url.Parse(finalURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	if strings.Contains(u.Path, "/maps/place/") {
+		business, err := s.Extract(inputURL, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return []*BusinessData{business}, nil
+	}
+
+	if !strings.Contains(u.Path, "/maps/search/") && !strings.Contains(u.Path, "/search") {
+		return nil, fmt.Errorf("unknown Google Maps URL type: %s", u.Path)
+	}
+
+	br := s.browser
+	if br == nil {
+		var err error
+		br, err = browser.NewBrowser(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start browser: %w", err)
+		}
+		defer br.Close()
+	}
+
+	deadlineCtx, deadlineCancel := context.WithTimeout(br.Context(), cfg.Timeout)
+	defer deadlineCancel()
+
+	tabCtx, tabCancel := br.NewTab(deadlineCtx)
+	defer tabCancel()
+
+	placeURLs, err := collectSearchResultURLs(tabCtx, finalURL, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect search results: %w", err)
+	}
+	log.Printf("[Scraper] Found %d result(s)", len(placeURLs))
+
+	// Reuse the Browser resolved above for every per-place extraction, so a
+	// Scraper created without one (NewScraper(nil)) doesn't start a fresh
+	// Chrome process per result.
+	extractor := s
+	if br != s.browser {
+		extractor = NewScraper(br)
+	}
+
+	var (
+		mu         sync.Mutex
+		businesses []*BusinessData
+		wg         sync.WaitGroup
+	)
+	for _, placeURL := range placeURLs {
+		wg.Add(1)
+		go func(placeURL string) {
+			defer wg.Done()
+			business, err := extractor.Extract(placeURL, cfg)
+			if err != nil {
+				log.Printf("[Scraper] ⚠ Failed to extract %.80s: %v", placeURL, err)
+				return
+			}
+			mu.Lock()
+			businesses = append(businesses, business)
+			mu.Unlock()
+		}(placeURL)
+	}
+	wg.Wait()
+
+	return businesses, nil
+}
+
+// collectSearchResultURLs navigates to searchURL and scrolls its results
+// feed, collecting deduplicated /maps/place/ hrefs until a scroll yields no
+// new ones or cfg.MaxResults is reached.
+func collectSearchResultURLs(ctx context.Context, searchURL string, cfg *Config) ([]string, error) {
+	if err := chromedp.Run(ctx,
+		chromedp.Navigate(searchURL),
+		chromedp.WaitReady("body"),
+		chromedp.Sleep(cfg.WaitTime),
+	); err != nil {
+		return nil, fmt.Errorf("failed to navigate to search page: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var ordered []string
+
+	const maxScrolls = 40
+	for i := 0; i < maxScrolls; i++ {
+		var hrefs []string
+		if err := chromedp.Run(ctx, chromedp.Evaluate(
+			`Array.from(document.querySelectorAll('`+resultsFeedSelector+` a[href*="/maps/place/"]')).map(a => a.href)`,
+			&hrefs,
+		)); err != nil {
+			return nil, fmt.Errorf("failed to read result cards: %w", err)
+		}
+
+		before := len(seen)
+		for _, href := range hrefs {
+			if !seen[href] {
+				seen[href] = true
+				ordered = append(ordered, href)
+			}
+		}
+
+		if cfg.MaxResults > 0 && len(ordered) >= cfg.MaxResults {
+			ordered = ordered[:cfg.MaxResults]
+			break
+		}
+
+		if len(seen) == before {
+			// Nothing new after scrolling means we've reached the end of
+			// the feed (or it never had one, e.g. a single-result page).
+			break
+		}
+
+		if err := chromedp.Run(ctx, chromedp.Evaluate(
+			`(() => { const feed = document.querySelector('`+resultsFeedSelector+`'); if (feed) feed.scrollTop = feed.scrollHeight; })()`,
+			nil,
+		)); err != nil {
+			return nil, fmt.Errorf("failed to scroll results feed: %w", err)
+		}
+		if err := chromedp.Run(ctx, chromedp.Sleep(1*time.Second)); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(ordered) == 0 {
+		return nil, fmt.Errorf("no results found on search page")
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,43 @@
+package scraper
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// allowedHosts are the only hosts ValidateGoogleMapsURL accepts: share.google
+// and Google's own maps/search domains (and their subdomains).
+var allowedHosts = []string{
+	"share.google",
+	"maps.google.com",
+	"www.google.com",
+	"google.com",
+	"goo.gl",
+}
+
+// ValidateGoogleMapsURL reports an error unless rawURL is a plain http(s) URL
+// on an allowedHosts domain or subdomain. Extract and ExtractAll call this
+// before making any network request (HTTP redirect-follow or chromedp
+// navigation) so that a caller passing an arbitrary URL - e.g. the server
+// package's "url" query parameter, or a batch input file - can't turn the
+// shared browser/HTTP client into an SSRF oracle against internal hosts.
+func ValidateGoogleMapsURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+
+	host := u.Hostname()
+	for _, allowed := range allowedHosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("not a Google Maps URL: %s", rawURL)
+}
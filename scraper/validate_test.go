@@ -0,0 +1,33 @@
+package scraper
+
+import "testing"
+
+func TestValidateGoogleMapsURL(t *testing.T) {
+	valid := []string{
+		"https://www.google.com/maps/place/Example",
+		"https://maps.google.com/maps?q=Example",
+		"https://goo.gl/maps/abc123",
+		"https://share.google/w4UZTre3NvPyC3b3Q",
+		"https://subdomain.google.com/maps/place/Example",
+	}
+	for _, u := range valid {
+		if err := ValidateGoogleMapsURL(u); err != nil {
+			t.Errorf("ValidateGoogleMapsURL(%q) = %v, want nil", u, err)
+		}
+	}
+
+	invalid := []string{
+		"http://169.254.169.254/latest/meta-data/%2fsearch", // SSRF: cloud metadata endpoint
+		"http://internal.example.com/maps/place/Example",    // unrelated host with a matching path
+		"http://evilgoogle.com/maps/place/Example",          // string-suffix bypass of "google.com"
+		"http://google.com.evil.com/maps/place/Example",     // host prefixed with the allowed domain
+		"ftp://www.google.com/maps/place/Example",           // disallowed scheme
+		"not a url at all",
+		"",
+	}
+	for _, u := range invalid {
+		if err := ValidateGoogleMapsURL(u); err == nil {
+			t.Errorf("ValidateGoogleMapsURL(%q) = nil, want an error", u)
+		}
+	}
+}
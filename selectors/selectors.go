@@ -0,0 +1,99 @@
+// Package selectors holds the CSS/XPath locators the scraper and image
+// extractor use to find business data on a rendered Maps page. Google
+// renames its generated class names (e.g. "RZ66Rb", "aoRNLd") often enough
+// that hardcoding them forces a fork-and-recompile every time one breaks;
+// this package loads them from a profile instead, so a user can dump the
+// built-in set, fix a broken entry, and point -selectors at their copy.
+package selectors
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kind identifies how a Selector's Query should be evaluated.
+type Kind string
+
+const (
+	CSS   Kind = "css"
+	XPath Kind = "xpath"
+)
+
+// Selector is one candidate locator for a field. Fields hold an ordered
+// slice of these, tried in turn until one matches.
+type Selector struct {
+	Query       string `yaml:"query"`
+	Kind        Kind   `yaml:"kind"`
+	Description string `yaml:"description"`
+}
+
+// Profile groups the ordered selector candidates for every field the
+// scraper and image extractor look for.
+type Profile struct {
+	Image   []Selector `yaml:"image"`
+	Name    []Selector `yaml:"name"`
+	Address []Selector `yaml:"address"`
+	Phone   []Selector `yaml:"phone"`
+	Website []Selector `yaml:"website"`
+	Hours   []Selector `yaml:"hours"`
+}
+
+//go:embed default.yaml
+var defaultYAML []byte
+
+var builtin = parseDefault()
+
+func parseDefault() *Profile {
+	var p Profile
+	if err := yaml.Unmarshal(defaultYAML, &p); err != nil {
+		panic(fmt.Sprintf("selectors: built-in default.yaml is invalid: %v", err))
+	}
+	return &p
+}
+
+// Default returns the built-in selector profile.
+func Default() *Profile {
+	return builtin
+}
+
+// EnvVar is the environment variable Resolve falls back to when flagPath is
+// empty.
+const EnvVar = "GMAPS2VCARD_SELECTORS"
+
+// Load reads a selector profile from a YAML file at path. JSON is valid
+// YAML, so a .json profile works too.
+func Load(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selector profile %s: %w", path, err)
+	}
+	var p Profile
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse selector profile %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Resolve returns the selector profile to use: flagPath if set, else
+// $GMAPS2VCARD_SELECTORS if set, else the built-in default.
+func Resolve(flagPath string) (*Profile, error) {
+	path := flagPath
+	if path == "" {
+		path = os.Getenv(EnvVar)
+	}
+	if path == "" {
+		return Default(), nil
+	}
+	return Load(path)
+}
+
+// Dump writes the built-in selector profile as YAML to w, so it can be
+// saved, edited, and passed back in via -selectors or $GMAPS2VCARD_SELECTORS.
+func Dump(w io.Writer) error {
+	_, err := w.Write(defaultYAML)
+	return err
+}
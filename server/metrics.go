@@ -0,0 +1,67 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// metrics tracks Prometheus-style counters and gauges for /metrics.
+//
+// Selector-hit distribution and per-field PageLoadTime/ExtractionTime are
+// not tracked: scraper.BusinessData carries no DebugInfo equivalent (unlike
+// imageextractor.Result and urlnormalizer.Result), so Scraper.Extract has
+// nothing to report them from. Exposing those would mean widening Extract's
+// return type across every caller (main, batch, server) for metrics alone;
+// out of scope here. What's below is everything Scraper.Extract can report.
+type metrics struct {
+	attempts  atomic.Int64
+	successes atomic.Int64
+	failures  atomic.Int64
+
+	mu            sync.Mutex
+	totalDuration time.Duration
+	samples       int64
+}
+
+func newMetrics() *metrics {
+	return &metrics{}
+}
+
+// observeDuration folds d into the running average extraction duration.
+func (m *metrics) observeDuration(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.totalDuration += d
+	m.samples++
+}
+
+func (m *metrics) averageDuration() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.samples == 0 {
+		return 0
+	}
+	return m.totalDuration / time.Duration(m.samples)
+}
+
+// Render writes the metrics in Prometheus text exposition format.
+func (m *metrics) Render(w io.Writer) {
+	fmt.Fprintln(w, "# HELP gmaps2vcard_extraction_attempts_total Total extraction attempts.")
+	fmt.Fprintln(w, "# TYPE gmaps2vcard_extraction_attempts_total counter")
+	fmt.Fprintf(w, "gmaps2vcard_extraction_attempts_total %d\n", m.attempts.Load())
+
+	fmt.Fprintln(w, "# HELP gmaps2vcard_extraction_successes_total Successful extractions.")
+	fmt.Fprintln(w, "# TYPE gmaps2vcard_extraction_successes_total counter")
+	fmt.Fprintf(w, "gmaps2vcard_extraction_successes_total %d\n", m.successes.Load())
+
+	fmt.Fprintln(w, "# HELP gmaps2vcard_extraction_failures_total Failed extractions.")
+	fmt.Fprintln(w, "# TYPE gmaps2vcard_extraction_failures_total counter")
+	fmt.Fprintf(w, "gmaps2vcard_extraction_failures_total %d\n", m.failures.Load())
+
+	fmt.Fprintln(w, "# HELP gmaps2vcard_extraction_duration_seconds_avg Average extraction duration.")
+	fmt.Fprintln(w, "# TYPE gmaps2vcard_extraction_duration_seconds_avg gauge")
+	fmt.Fprintf(w, "gmaps2vcard_extraction_duration_seconds_avg %f\n", m.averageDuration().Seconds())
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter enforces a fixed-window per-IP request limit.
+type rateLimiter struct {
+	limit int // requests allowed per window; <= 0 disables limiting
+
+	mu      sync.Mutex
+	windows map[string]*window
+}
+
+type window struct {
+	count int
+	reset time.Time
+}
+
+func newRateLimiter(requestsPerMinute int) *rateLimiter {
+	return &rateLimiter{
+		limit:   requestsPerMinute,
+		windows: make(map[string]*window),
+	}
+}
+
+// Allow reports whether ip is still under its limit for the current window,
+// counting this call toward that window.
+func (l *rateLimiter) Allow(ip string) bool {
+	if l.limit <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	w, ok := l.windows[ip]
+	if !ok || now.After(w.reset) {
+		w = &window{reset: now.Add(time.Minute)}
+		l.windows[ip] = w
+	}
+
+	w.count++
+	return w.count <= l.limit
+}
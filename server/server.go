@@ -0,0 +1,169 @@
+// Package server exposes Google Maps extraction as an HTTP API, reusing a
+// single shared browser session across requests instead of launching Chrome
+// per call.
+package server
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"gmaps2vcard/browser"
+	"gmaps2vcard/encoder"
+	"gmaps2vcard/scraper"
+)
+
+// Config configures the extraction HTTP server.
+type Config struct {
+	Addr              string
+	RequestsPerMinute int // per-IP rate limit; 0 disables limiting
+	ScraperConfig     *scraper.Config
+}
+
+// DefaultConfig returns sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Addr:              ":8080",
+		RequestsPerMinute: 30,
+		ScraperConfig:     scraper.DefaultConfig(),
+	}
+}
+
+// Server exposes business-data extraction as a JSON/vCard/image REST API,
+// backed by a single shared Browser.
+type Server struct {
+	config  *Config
+	browser *browser.Browser
+	scraper *scraper.Scraper
+	limiter *rateLimiter
+	metrics *metrics
+}
+
+// New starts a shared Browser and returns a Server ready to ListenAndServe.
+func New(config *Config) (*Server, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	br, err := browser.NewBrowser(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start browser: %w", err)
+	}
+
+	return &Server{
+		config:  config,
+		browser: br,
+		scraper: scraper.NewScraper(br),
+		limiter: newRateLimiter(config.RequestsPerMinute),
+		metrics: newMetrics(),
+	}, nil
+}
+
+// Close shuts down the shared browser session.
+func (s *Server) Close() {
+	s.browser.Close()
+}
+
+// ListenAndServe starts the HTTP server and blocks until it exits.
+func (s *Server) ListenAndServe() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vcard", s.withRateLimit(s.handleFormat(encoder.VCardEncoder{}, "text/vcard")))
+	mux.HandleFunc("/json", s.withRateLimit(s.handleFormat(encoder.JSONEncoder{}, "application/json")))
+	mux.HandleFunc("/csv", s.withRateLimit(s.handleFormat(encoder.CSVEncoder{}, "text/csv")))
+	mux.HandleFunc("/schemaorg", s.withRateLimit(s.handleFormat(encoder.SchemaOrgEncoder{}, "application/ld+json")))
+	mux.HandleFunc("/image", s.withRateLimit(s.handleImage))
+	mux.HandleFunc("/metrics", s.handleMetrics)
+
+	log.Printf("[Server] Listening on %s", s.config.Addr)
+	return http.ListenAndServe(s.config.Addr, mux)
+}
+
+// withRateLimit rejects requests beyond Config.RequestsPerMinute for a
+// given client IP with 429 Too Many Requests.
+func (s *Server) withRateLimit(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.limiter.Allow(clientIP(r)) {
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// extract resolves the "url" query parameter and records attempt/success/
+// failure/duration metrics for it.
+func (s *Server) extract(r *http.Request) (*scraper.BusinessData, error) {
+	mapsURL := r.URL.Query().Get("url")
+	if mapsURL == "" {
+		return nil, fmt.Errorf("missing required 'url' query parameter")
+	}
+
+	start := time.Now()
+	s.metrics.attempts.Add(1)
+
+	business, err := s.scraper.Extract(mapsURL, s.config.ScraperConfig)
+
+	s.metrics.observeDuration(time.Since(start))
+	if err != nil {
+		s.metrics.failures.Add(1)
+		return nil, err
+	}
+	s.metrics.successes.Add(1)
+	return business, nil
+}
+
+// handleFormat returns a handler that extracts the requested business and
+// renders it with enc under contentType. /vcard, /json, /csv, and
+// /schemaorg all share this, one encoder.Encoder apiece.
+func (s *Server) handleFormat(enc encoder.Encoder, contentType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		business, err := s.extract(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		if err := enc.Encode(w, business); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+func (s *Server) handleImage(w http.ResponseWriter, r *http.Request) {
+	business, err := s.extract(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	if business.PhotoURL == "" {
+		http.Error(w, "no image found", http.StatusNotFound)
+		return
+	}
+
+	resp, err := http.Get(business.PhotoURL)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to fetch image: %v", err), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	w.Header().Set("Content-Type", "image/jpeg")
+	io.Copy(w, resp.Body)
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	s.metrics.Render(w)
+}
+
+// clientIP returns the request's IP, stripped of its port.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,173 @@
+// Package transport layers proxy rotation and block/CAPTCHA retries over the
+// HTTP and chromedp fetch paths, so a single blocked IP doesn't kill an
+// entire batch run.
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// requestTimeout bounds each individual attempt Get makes, so a proxy that
+// hangs rather than responding doesn't stall the whole retry loop.
+const requestTimeout = 10 * time.Second
+
+// Config controls proxy rotation and block retries. The zero value is a
+// usable Config with no proxies and no retries.
+type Config struct {
+	// Proxies is a round-robin pool of http(s):// or socks5:// proxy URLs.
+	// Nil or empty means requests go out directly.
+	Proxies []string
+
+	// MaxRetries caps how many additional proxies (and, via configureReq,
+	// User-Agents) Get tries after a blocked response before giving up with
+	// ErrBlocked.
+	MaxRetries int
+
+	poolOnce sync.Once
+	pool     *ProxyPool
+}
+
+// DefaultConfig returns a Config with no proxies and 3 retries.
+func DefaultConfig() *Config {
+	return &Config{MaxRetries: 3}
+}
+
+// proxyPool lazily builds Config's ProxyPool from Proxies on first use, so
+// repeated Get calls against the same Config keep rotating instead of each
+// starting over from Proxies[0].
+func (c *Config) proxyPool() *ProxyPool {
+	c.poolOnce.Do(func() {
+		c.pool = NewProxyPool(c.Proxies)
+	})
+	return c.pool
+}
+
+// NextProxy returns the next proxy URL in c's rotation, or "" if c is nil or
+// has no proxies configured. Exposed for callers that need to commit to one
+// proxy up front (e.g. routing an entire chromedp Browser process through
+// it) rather than per-request via Get.
+func (c *Config) NextProxy() string {
+	if c == nil {
+		return ""
+	}
+	return c.proxyPool().Next()
+}
+
+// ErrBlocked is returned once a request has exhausted Config.MaxRetries
+// against the current block/CAPTCHA signal, so callers can back off instead
+// of retrying forever.
+type ErrBlocked struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *ErrBlocked) Error() string {
+	return fmt.Sprintf("blocked fetching %s (HTTP %d) after exhausting retries", e.URL, e.StatusCode)
+}
+
+// ProxyPool hands out proxy URLs round-robin. The zero value (no proxies)
+// is valid; Next then always returns "".
+type ProxyPool struct {
+	proxies []string
+	next    uint32
+}
+
+// NewProxyPool returns a ProxyPool cycling through proxies in order.
+func NewProxyPool(proxies []string) *ProxyPool {
+	return &ProxyPool{proxies: proxies}
+}
+
+// Next returns the next proxy URL in the rotation, or "" if the pool is empty.
+func (p *ProxyPool) Next() string {
+	if p == nil || len(p.proxies) == 0 {
+		return ""
+	}
+	i := atomic.AddUint32(&p.next, 1) - 1
+	return p.proxies[i%uint32(len(p.proxies))]
+}
+
+// isBlocked reports whether resp matches a known CAPTCHA/interstitial signal:
+// Google's /sorry/ interstitial, or an outright 403/429.
+func isBlocked(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return strings.Contains(resp.Request.URL.Path, "/sorry/")
+}
+
+// Get issues a GET against targetURL, retrying against the next proxy in
+// cfg.Proxies whenever the response looks blocked (see isBlocked).
+// configureReq is invoked on every attempt's fresh request so callers can
+// set a new User-Agent and other headers per retry, not just per proxy.
+// Returns ErrBlocked once cfg.MaxRetries is exhausted.
+func Get(targetURL string, cfg *Config, configureReq func(*http.Request)) (*http.Response, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	pool := cfg.proxyPool()
+
+	var lastStatus int
+	for attempt := 0; attempt <= cfg.MaxRetries; attempt++ {
+		req, err := http.NewRequest(http.MethodGet, targetURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		if configureReq != nil {
+			configureReq(req)
+		}
+
+		client, err := clientFor(pool.Next())
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+
+		if !isBlocked(resp) {
+			return resp, nil
+		}
+
+		lastStatus = resp.StatusCode
+		resp.Body.Close()
+	}
+
+	return nil, &ErrBlocked{URL: targetURL, StatusCode: lastStatus}
+}
+
+// clientFor returns an *http.Client routed through proxyURL, or a plain
+// client if proxyURL is empty.
+func clientFor(proxyURL string) (*http.Client, error) {
+	if proxyURL == "" {
+		return &http.Client{Timeout: requestTimeout}, nil
+	}
+
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+	}
+
+	return &http.Client{
+		Timeout:   requestTimeout,
+		Transport: &http.Transport{Proxy: http.ProxyURL(parsed)},
+	}, nil
+}
+
+// ChromedpOptions returns the exec allocator options needed to route chromedp
+// through proxyURL, or nil if proxyURL is empty.
+func ChromedpOptions(proxyURL string) []chromedp.ExecAllocatorOption {
+	if proxyURL == "" {
+		return nil
+	}
+	return []chromedp.ExecAllocatorOption{chromedp.ProxyServer(proxyURL)}
+}
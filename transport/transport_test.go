@@ -0,0 +1,58 @@
+package transport
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestIsBlocked(t *testing.T) {
+	mustURL := func(raw string) *url.URL {
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatalf("url.Parse(%q) failed: %v", raw, err)
+		}
+		return u
+	}
+
+	cases := []struct {
+		name string
+		resp *http.Response
+		want bool
+	}{
+		{"403", &http.Response{StatusCode: http.StatusForbidden, Request: &http.Request{URL: mustURL("https://example.com/")}}, true},
+		{"429", &http.Response{StatusCode: http.StatusTooManyRequests, Request: &http.Request{URL: mustURL("https://example.com/")}}, true},
+		{"sorry interstitial", &http.Response{StatusCode: http.StatusOK, Request: &http.Request{URL: mustURL("https://www.google.com/sorry/index")}}, true},
+		{"ok", &http.Response{StatusCode: http.StatusOK, Request: &http.Request{URL: mustURL("https://example.com/")}}, false},
+	}
+
+	for _, c := range cases {
+		if got := isBlocked(c.resp); got != c.want {
+			t.Errorf("%s: isBlocked() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestProxyPoolNext(t *testing.T) {
+	pool := NewProxyPool([]string{"http://a", "http://b", "http://c"})
+
+	got := []string{pool.Next(), pool.Next(), pool.Next(), pool.Next()}
+	want := []string{"http://a", "http://b", "http://c", "http://a"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Next() #%d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestProxyPoolNextEmpty(t *testing.T) {
+	pool := NewProxyPool(nil)
+	if got := pool.Next(); got != "" {
+		t.Errorf("Next() on an empty pool = %q, want \"\"", got)
+	}
+
+	var nilPool *ProxyPool
+	if got := nilPool.Next(); got != "" {
+		t.Errorf("Next() on a nil pool = %q, want \"\"", got)
+	}
+}
@@ -0,0 +1,307 @@
+package urlnormalizer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+
+	"gmaps2vcard/useragent"
+)
+
+// Browser drives the single page extractFromSearchPage loads and queries.
+// It embeds Page so any Browser can also serve as the Page a Strategy runs
+// against. ChromedpBrowser is the default; RodBrowser is available when
+// chromedp's automation fingerprint gets flagged more than Rod's does.
+type Browser interface {
+	Page
+
+	// Context returns the browser's underlying page context. Callers derive
+	// per-call timeouts from it (context.WithTimeout) before passing them
+	// back into Navigate, WaitReady, Attribute, Eval, or Location.
+	Context() context.Context
+
+	// Navigate loads url and blocks until navigation completes.
+	Navigate(ctx context.Context, url string) error
+	// WaitReady blocks until selector is present in the DOM.
+	WaitReady(ctx context.Context, selector string) error
+	// Location returns the page's current URL, which may differ from the
+	// last Navigate target after a client-side redirect.
+	Location(ctx context.Context) (string, error)
+
+	// SetCookies seeds the browser with cookies before navigation, e.g. from
+	// Config.CookieJar, so a consent or CAPTCHA cookie solved in a real
+	// browser carries over instead of hitting a fresh challenge every run.
+	SetCookies(ctx context.Context, cookies []*http.Cookie) error
+	// Cookies returns the browser's current cookies for url, so a caller
+	// can save them back to Config.CookieJar once a run solves a CAPTCHA.
+	Cookies(ctx context.Context, url string) ([]*http.Cookie, error)
+
+	// Close releases the browser process and any resources it holds.
+	Close() error
+}
+
+// BrowserFactory creates the Browser backend extractFromSearchPage drives.
+// Config.Browser holds one so callers can pick chromedp vs Rod, reuse an
+// existing user-data-dir, or inject a stub for tests.
+type BrowserFactory func() (Browser, error)
+
+// ChromedpBrowserOptions configures NewChromedpBrowser.
+type ChromedpBrowserOptions struct {
+	// UserAgent picks a fresh useragent.RandomFor(useragent.Chrome) when empty.
+	UserAgent string
+	// UserDataDir, if set, points Chrome at a persistent profile directory
+	// so cookies - notably Google's CAPTCHA/consent cookie - survive
+	// between runs instead of resetting with every fresh process.
+	UserDataDir string
+}
+
+// ChromedpBrowser is the Browser implementation extractFromSearchPage used
+// before this package supported swapping backends: one private Chrome
+// process per call, driven over the Chrome DevTools Protocol.
+type ChromedpBrowser struct {
+	allocCancel context.CancelFunc
+	ctx         context.Context
+	cancel      context.CancelFunc
+}
+
+// NewChromedpBrowser launches a Chrome process configured with opts.
+func NewChromedpBrowser(opts ChromedpBrowserOptions) (Browser, error) {
+	ua := opts.UserAgent
+	if ua == "" {
+		ua = useragent.RandomFor(useragent.Chrome)
+	}
+
+	execOpts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.UserAgent(ua),
+		chromedp.Flag("disable-blink-features", "AutomationControlled"),
+		chromedp.Flag("exclude-switches", "enable-automation"),
+		chromedp.Flag("enable-features", "NetworkService,NetworkServiceInProcess"),
+		chromedp.WindowSize(1920, 1080),
+		chromedp.Flag("lang", "en-US,en"),
+		chromedp.Flag("headless", true),
+	)
+	if opts.UserDataDir != "" {
+		execOpts = append(execOpts, chromedp.UserDataDir(opts.UserDataDir))
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), execOpts...)
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		allocCancel()
+		return nil, fmt.Errorf("failed to start chromedp browser: %w", err)
+	}
+
+	return &ChromedpBrowser{allocCancel: allocCancel, ctx: ctx, cancel: cancel}, nil
+}
+
+func (b *ChromedpBrowser) Context() context.Context { return b.ctx }
+
+func (b *ChromedpBrowser) Navigate(ctx context.Context, url string) error {
+	return chromedp.Run(ctx, chromedp.Navigate(url))
+}
+
+func (b *ChromedpBrowser) WaitReady(ctx context.Context, selector string) error {
+	return chromedp.Run(ctx, chromedp.WaitReady(selector, chromedp.ByQuery))
+}
+
+func (b *ChromedpBrowser) Attribute(ctx context.Context, selector, attr string) (string, bool, error) {
+	var value string
+	var ok bool
+	err := chromedp.Run(ctx, chromedp.AttributeValue(selector, attr, &value, &ok, chromedp.ByQuery))
+	return value, ok, err
+}
+
+func (b *ChromedpBrowser) Eval(ctx context.Context, js string, out interface{}) error {
+	return chromedp.Run(ctx, chromedp.Evaluate(js, out))
+}
+
+func (b *ChromedpBrowser) Location(ctx context.Context) (string, error) {
+	var pageURL string
+	if err := chromedp.Run(ctx, chromedp.Location(&pageURL)); err != nil {
+		return "", err
+	}
+	return pageURL, nil
+}
+
+func (b *ChromedpBrowser) SetCookies(ctx context.Context, cookies []*http.Cookie) error {
+	if len(cookies) == 0 {
+		return nil
+	}
+	return chromedp.Run(ctx, network.SetCookies(cookieParamsFromHTTP(cookies)))
+}
+
+func (b *ChromedpBrowser) Cookies(ctx context.Context, url string) ([]*http.Cookie, error) {
+	var cdpCookies []*network.Cookie
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		cdpCookies, err = network.GetCookies().WithUrls([]string{url}).Do(ctx)
+		return err
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return httpCookiesFromCDP(cdpCookies), nil
+}
+
+// cookieParamsFromHTTP converts cookies to the CDP shape network.SetCookies
+// expects, split out from SetCookies so the conversion can be unit tested
+// without a live browser.
+func cookieParamsFromHTTP(cookies []*http.Cookie) []*network.CookieParam {
+	params := make([]*network.CookieParam, len(cookies))
+	for i, c := range cookies {
+		param := &network.CookieParam{Name: c.Name, Value: c.Value, Domain: c.Domain, Path: c.Path}
+		if !c.Expires.IsZero() {
+			expires := cdp.TimeSinceEpoch(c.Expires)
+			param.Expires = &expires
+		}
+		params[i] = param
+	}
+	return params
+}
+
+// httpCookiesFromCDP is cookieParamsFromHTTP's counterpart for Cookies.
+func httpCookiesFromCDP(cdpCookies []*network.Cookie) []*http.Cookie {
+	cookies := make([]*http.Cookie, len(cdpCookies))
+	for i, c := range cdpCookies {
+		cookies[i] = &http.Cookie{Name: c.Name, Value: c.Value, Domain: c.Domain, Path: c.Path}
+	}
+	return cookies
+}
+
+func (b *ChromedpBrowser) Close() error {
+	b.cancel()
+	b.allocCancel()
+	return nil
+}
+
+// RodBrowserOptions configures NewRodBrowser.
+type RodBrowserOptions struct {
+	// UserAgent picks a fresh useragent.RandomFor(useragent.Chrome) when empty.
+	UserAgent string
+	// UserDataDir, if set, points the launched Chrome at a persistent
+	// profile directory, the same benefit ChromedpBrowserOptions.UserDataDir
+	// gives the chromedp backend.
+	UserDataDir string
+}
+
+// RodBrowser is a Browser backed by github.com/go-rod/rod, whose action
+// loop retries element lookups and waits on its own rather than requiring
+// chromedp's explicit WaitReady/Sleep choreography - often more resilient
+// against Google's DOM settling late.
+type RodBrowser struct {
+	browser *rod.Browser
+	page    *rod.Page
+}
+
+// NewRodBrowser launches Chrome through Rod's launcher and opens one page.
+func NewRodBrowser(opts RodBrowserOptions) (Browser, error) {
+	l := launcher.New().Headless(true)
+	if opts.UserDataDir != "" {
+		l = l.UserDataDir(opts.UserDataDir)
+	}
+
+	controlURL, err := l.Launch()
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch rod browser: %w", err)
+	}
+
+	browser := rod.New().ControlURL(controlURL)
+	if err := browser.Connect(); err != nil {
+		return nil, fmt.Errorf("failed to connect to rod browser: %w", err)
+	}
+
+	page, err := browser.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		browser.Close()
+		return nil, fmt.Errorf("failed to open rod page: %w", err)
+	}
+
+	ua := opts.UserAgent
+	if ua == "" {
+		ua = useragent.RandomFor(useragent.Chrome)
+	}
+	if err := page.SetUserAgent(&proto.NetworkSetUserAgentOverride{UserAgent: ua}); err != nil {
+		browser.Close()
+		return nil, fmt.Errorf("failed to set rod user agent: %w", err)
+	}
+
+	return &RodBrowser{browser: browser, page: page}, nil
+}
+
+func (b *RodBrowser) Context() context.Context { return b.page.GetContext() }
+
+func (b *RodBrowser) Navigate(ctx context.Context, url string) error {
+	return b.page.Context(ctx).Navigate(url)
+}
+
+func (b *RodBrowser) WaitReady(ctx context.Context, selector string) error {
+	_, err := b.page.Context(ctx).Element(selector)
+	return err
+}
+
+func (b *RodBrowser) Attribute(ctx context.Context, selector, attr string) (string, bool, error) {
+	el, err := b.page.Context(ctx).Element(selector)
+	if err != nil {
+		return "", false, nil // no match isn't a failure worth surfacing - just "not found"
+	}
+	value, err := el.Attribute(attr)
+	if err != nil {
+		return "", false, err
+	}
+	if value == nil {
+		return "", false, nil
+	}
+	return *value, true, nil
+}
+
+func (b *RodBrowser) Eval(ctx context.Context, js string, out interface{}) error {
+	res, err := b.page.Context(ctx).Eval(js)
+	if err != nil {
+		return err
+	}
+	return res.Value.Unmarshal(out)
+}
+
+func (b *RodBrowser) Location(ctx context.Context) (string, error) {
+	info, err := b.page.Context(ctx).Info()
+	if err != nil {
+		return "", err
+	}
+	return info.URL, nil
+}
+
+func (b *RodBrowser) SetCookies(ctx context.Context, cookies []*http.Cookie) error {
+	if len(cookies) == 0 {
+		return nil
+	}
+	params := make([]*proto.NetworkCookieParam, len(cookies))
+	for i, c := range cookies {
+		params[i] = &proto.NetworkCookieParam{Name: c.Name, Value: c.Value, Domain: c.Domain, Path: c.Path}
+	}
+	return b.page.Context(ctx).SetCookies(params)
+}
+
+func (b *RodBrowser) Cookies(ctx context.Context, url string) ([]*http.Cookie, error) {
+	rodCookies, err := b.page.Context(ctx).Cookies([]string{url})
+	if err != nil {
+		return nil, err
+	}
+
+	cookies := make([]*http.Cookie, len(rodCookies))
+	for i, c := range rodCookies {
+		cookies[i] = &http.Cookie{Name: c.Name, Value: c.Value, Domain: c.Domain, Path: c.Path}
+	}
+	return cookies, nil
+}
+
+func (b *RodBrowser) Close() error {
+	return b.browser.Close()
+}
@@ -0,0 +1,45 @@
+package urlnormalizer
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestCookieParamsFromHTTP(t *testing.T) {
+	expires := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cookies := []*http.Cookie{
+		{Name: "a", Value: "1", Domain: "example.com", Path: "/"},
+		{Name: "b", Value: "2", Domain: "example.com", Path: "/", Expires: expires},
+	}
+
+	params := cookieParamsFromHTTP(cookies)
+	if len(params) != 2 {
+		t.Fatalf("expected 2 params, got %d", len(params))
+	}
+	if params[0].Expires != nil {
+		t.Errorf("expected no Expires for a cookie with a zero Expires, got %v", params[0].Expires)
+	}
+	if params[1].Expires == nil {
+		t.Fatalf("expected Expires to be set for cookie b")
+	}
+	if got := time.Time(*params[1].Expires); !got.Equal(expires) {
+		t.Errorf("Expires = %v, want %v", got, expires)
+	}
+}
+
+func TestHTTPCookiesFromCDP(t *testing.T) {
+	cdpCookies := []*network.Cookie{
+		{Name: "a", Value: "1", Domain: "example.com", Path: "/"},
+	}
+
+	cookies := httpCookiesFromCDP(cdpCookies)
+	if len(cookies) != 1 {
+		t.Fatalf("expected 1 cookie, got %d", len(cookies))
+	}
+	if cookies[0].Name != "a" || cookies[0].Value != "1" || cookies[0].Domain != "example.com" || cookies[0].Path != "/" {
+		t.Errorf("unexpected cookie: %+v", cookies[0])
+	}
+}
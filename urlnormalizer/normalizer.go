@@ -3,13 +3,16 @@ package urlnormalizer
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"time"
 
-	"github.com/chromedp/chromedp"
+	"gmaps2vcard/useragent"
 )
 
 // DebugLevel controls the verbosity of logging
@@ -22,19 +25,88 @@ const (
 	DebugVeryVerbose
 )
 
+// LevelVeryVerbose is a custom slog level below LevelDebug (-4), used for the
+// chattiest logVeryVerbose records (per-strategy attempts, raw timeouts) that
+// would otherwise drown out DebugVerbose's Debug-level output.
+const LevelVeryVerbose = slog.Level(-8)
+
+// levelFor maps a DebugLevel onto the slog.Level defaultLogger gates its
+// handler at: DebugBasic->Info, DebugVerbose->Debug, DebugVeryVerbose->
+// LevelVeryVerbose. DebugNone gates above all of them, so nothing is logged.
+func levelFor(d DebugLevel) slog.Level {
+	switch d {
+	case DebugVeryVerbose:
+		return LevelVeryVerbose
+	case DebugVerbose:
+		return slog.LevelDebug
+	case DebugBasic:
+		return slog.LevelInfo
+	default:
+		return slog.LevelError + 1
+	}
+}
+
+// defaultLogger builds the *slog.Logger NewNormalizer falls back to when
+// Config.Logger is nil: JSON records gated at the level DebugLevel maps to,
+// written to stderr and, if logFile is set, teed into that file too so a
+// batch run can be grepped across many normalizations after the fact.
+func defaultLogger(debugLevel DebugLevel, logFile string) *slog.Logger {
+	writer := io.Writer(os.Stderr)
+	if logFile != "" {
+		f, err := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			log.Printf("[URLNormalizer] failed to open log file %s, logging to stderr only: %v", logFile, err)
+		} else {
+			writer = io.MultiWriter(os.Stderr, f)
+		}
+	}
+
+	handler := slog.NewJSONHandler(writer, &slog.HandlerOptions{Level: levelFor(debugLevel)})
+	return slog.New(handler)
+}
+
 // Config holds configuration for the URL normalizer
 type Config struct {
 	DebugLevel DebugLevel
 	Timeout    time.Duration
 	WaitTime   time.Duration
+
+	// StrategyRetries caps how many additional attempts extractFromSearchPage
+	// gives each Strategy before moving on to the next one.
+	StrategyRetries int
+	// StrategyBackoff is the delay before a strategy's second attempt; it
+	// doubles on every subsequent retry.
+	StrategyBackoff time.Duration
+
+	// Browser creates the Browser backend extractFromSearchPage drives. Nil
+	// selects NewChromedpBrowser with default options. Override to pick Rod,
+	// reuse an existing user-data-dir, or inject a stub for tests.
+	Browser BrowserFactory
+
+	// CookieJar, if set, is loaded for the search URL before navigation and
+	// saved back after, so a user who solves a CAPTCHA once in their real
+	// browser can export its cookies and unblock this tool.
+	CookieJar http.CookieJar
+
+	// Logger receives every record Normalize and extractFromSearchPage emit.
+	// Nil builds one via defaultLogger, gated at the level DebugLevel maps to
+	// (see levelFor) and, if LogFile is set, teed into that file alongside
+	// stderr.
+	Logger *slog.Logger
+	// LogFile, if set and Logger is nil, additionally writes every log
+	// record as JSON to this file.
+	LogFile string
 }
 
 // DefaultConfig returns a config with sensible defaults
 func DefaultConfig() *Config {
 	return &Config{
-		DebugLevel: DebugVerbose,
-		Timeout:    45 * time.Second,
-		WaitTime:   3 * time.Second,
+		DebugLevel:      DebugVerbose,
+		Timeout:         45 * time.Second,
+		WaitTime:        3 * time.Second,
+		StrategyRetries: 2,
+		StrategyBackoff: 500 * time.Millisecond,
+		Browser:         func() (Browser, error) { return NewChromedpBrowser(ChromedpBrowserOptions{}) },
 	}
 }
 
@@ -71,15 +143,45 @@ type SearchAttempt struct {
 
 // Normalizer handles URL normalization to Google Maps place URLs
 type Normalizer struct {
-	config *Config
+	config     *Config
+	strategies []Strategy
 }
 
-// NewNormalizer creates a new URL normalizer
+// NewNormalizer creates a new URL normalizer, pre-loaded with the built-in
+// extraction strategies in the order they're tried.
 func NewNormalizer(config *Config) *Normalizer {
 	if config == nil {
 		config = DefaultConfig()
 	}
-	return &Normalizer{config: config}
+	if config.Browser == nil {
+		config.Browser = func() (Browser, error) { return NewChromedpBrowser(ChromedpBrowserOptions{}) }
+	}
+	if config.Logger == nil {
+		config.Logger = defaultLogger(config.DebugLevel, config.LogFile)
+	}
+	return &Normalizer{
+		config: config,
+		strategies: []Strategy{
+			hrefFromAddressCard{},
+			dataURLAttr{},
+			ldJSONLocalBusiness{},
+			directionsAnchor{},
+		},
+	}
+}
+
+// RegisterStrategy appends s to the end of n's strategy list, tried after
+// every strategy already registered. Use this to extend extraction from
+// calling code without forking the package.
+func (n *Normalizer) RegisterStrategy(s Strategy) {
+	n.strategies = append(n.strategies, s)
+}
+
+// SetStrategies replaces n's entire strategy list, in the order they'll be
+// tried. Callers that want to drop the built-ins - to reorder them, or to
+// plug in a stub Strategy for tests - use this instead of RegisterStrategy.
+func (n *Normalizer) SetStrategies(strategies []Strategy) {
+	n.strategies = strategies
 }
 
 // Normalize takes any Google Maps URL and normalizes it to a /maps/place/ URL
@@ -92,21 +194,20 @@ func (n *Normalizer) Normalize(inputURL string) *Result {
 		},
 	}
 
-	n.logBasic("=== Starting URL Normalization ===")
-	n.logBasic("Input URL: %s", inputURL)
+	n.logBasic("starting URL normalization", "input_url", inputURL)
 
 	// Step 1: Follow all redirects
 	redirectStart := time.Now()
-	n.logVerbose("→ Following redirects...")
+	n.logVerbose("following redirects")
 	finalURL, err := n.followRedirects(inputURL)
 	if err != nil {
 		result.Error = fmt.Errorf("failed to follow redirects: %w", err)
-		n.logBasic("✗ Redirect failed: %v", err)
+		n.logBasic("redirect failed", "error", err)
 		return result
 	}
 	result.DebugInfo.RedirectedURL = finalURL
 	result.DebugInfo.RedirectTime = time.Since(redirectStart)
-	n.logVerbose("✓ Redirected to: %s", finalURL)
+	n.logVerbose("redirected", "redirected_url", finalURL)
 
 	// Step 2: Parse and detect URL type
 	u, err := url.Parse(finalURL)
@@ -117,7 +218,7 @@ func (n *Normalizer) Normalize(inputURL string) *Result {
 
 	// Case 1: Already a /maps/place/ URL - perfect!
 	if strings.Contains(u.Path, "/maps/place/") {
-		n.logBasic("✓ Already a maps/place URL")
+		n.logBasic("already a maps/place URL")
 		result.NormalizedURL = finalURL
 		result.URLType = "direct"
 		result.Success = true
@@ -128,7 +229,7 @@ func (n *Normalizer) Normalize(inputURL string) *Result {
 
 	// Case 2: It's a /search URL - need to extract the maps/place link
 	if strings.Contains(u.Path, "/search") {
-		n.logBasic("→ Detected search page, extracting maps/place link...")
+		n.logBasic("detected search page, extracting maps/place link")
 		result.DebugInfo.DetectedType = "search page"
 
 		extractionStart := time.Now()
@@ -137,24 +238,24 @@ func (n *Normalizer) Normalize(inputURL string) *Result {
 
 		if err != nil {
 			result.Error = err
-			n.logBasic("✗ Extraction failed: %v", err)
+			n.logBasic("extraction failed", "error", err)
 			return result
 		}
 
 		result.NormalizedURL = mapsURL
 		result.URLType = "search->place"
 		result.Success = true
-		n.logBasic("✓ Normalized to: %s", mapsURL)
+		n.logBasic("normalized", "normalized_url", mapsURL)
 	} else {
 		// Case 3: Unknown URL type
 		result.Error = fmt.Errorf("unknown Google Maps URL type: %s\nPlease provide either:\n  - A share.google link\n  - A direct maps/place URL\n  - Or check if Google changed their URL structure", finalURL)
 		result.DebugInfo.DetectedType = "unknown"
-		n.logBasic("✗ Unknown URL type: %s", u.Path)
+		n.logBasic("unknown URL type", "path", u.Path)
 		return result
 	}
 
 	result.DebugInfo.TotalTime = time.Since(startTime)
-	n.logBasic("=== Normalization Complete (total: %v) ===", result.DebugInfo.TotalTime)
+	n.logBasic("normalization complete", "duration_ms", result.DebugInfo.TotalTime.Milliseconds())
 	return result
 }
 
@@ -174,7 +275,7 @@ func (n *Normalizer) followRedirects(inputURL string) (string, error) {
 
 	// Legitimate browser headers for personal use
 	// Mimicking real Chrome on macOS to avoid triggering bot detection
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("User-Agent", useragent.Random())
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7")
 	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
 	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
@@ -212,150 +313,129 @@ func (n *Normalizer) followRedirects(inputURL string) (string, error) {
 
 // extractFromSearchPage navigates a Google search page and extracts the maps/place link
 func (n *Normalizer) extractFromSearchPage(searchURL string, debugInfo *DebugInfo) (string, error) {
-	// Set up chromedp with legitimate browser fingerprint for personal use
-	// Using realistic macOS Safari/Chrome headers to avoid triggering bot detection
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		// Modern Chrome on macOS
-		chromedp.UserAgent("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
-
-		// Disable automation indicators
-		chromedp.Flag("disable-blink-features", "AutomationControlled"),
-		chromedp.Flag("exclude-switches", "enable-automation"),
-
-		// Enable features that real browsers have
-		chromedp.Flag("enable-features", "NetworkService,NetworkServiceInProcess"),
-
-		// Standard window size (not headless indicator sizes)
-		chromedp.WindowSize(1920, 1080),
-
-		// Accept language for personal browsing
-		chromedp.Flag("lang", "en-US,en"),
-
-		// Run headless but with modern mode
-		chromedp.Flag("headless", true),
-	)
-
-	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), opts...)
-	defer allocCancel()
-
-	ctx, ctxCancel := chromedp.NewContext(allocCtx)
-	defer ctxCancel()
+	br, err := n.config.Browser()
+	if err != nil {
+		return "", fmt.Errorf("failed to start browser: %w", err)
+	}
+	defer br.Close()
 
-	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, n.config.Timeout)
-	defer timeoutCancel()
+	ctx, cancel := context.WithTimeout(br.Context(), n.config.Timeout)
+	defer cancel()
 
-	ctx = timeoutCtx
+	n.logVerbose("navigating to search page")
+	n.logVeryVerbose("search page config", "timeout", n.config.Timeout, "wait_time", n.config.WaitTime)
 
-	n.logVerbose("→ Navigating to search page...")
-	n.logVeryVerbose("Timeout set to: %v", n.config.Timeout)
-	n.logVeryVerbose("Wait time set to: %v", n.config.WaitTime)
+	if n.config.CookieJar != nil {
+		if u, err := url.Parse(searchURL); err == nil {
+			if cookies := n.config.CookieJar.Cookies(u); len(cookies) > 0 {
+				if err := br.SetCookies(ctx, cookies); err != nil {
+					n.logVeryVerbose("failed to load cookie jar", "error", err)
+				}
+			}
+		}
+	}
 
-	var pageTitle, pageURL string
+	if err := br.Navigate(ctx, searchURL); err != nil {
+		n.logVeryVerbose("navigate failed", "error", err)
+		return "", fmt.Errorf("failed to navigate: %w", err)
+	}
+	if err := br.WaitReady(ctx, "body"); err != nil {
+		n.logVeryVerbose("wait ready failed", "error", err)
+		return "", fmt.Errorf("failed to navigate: %w", err)
+	}
+	time.Sleep(n.config.WaitTime)
 
-	// Navigate and get page info
-	n.logVeryVerbose("Starting chromedp.Run...")
-	err := chromedp.Run(ctx,
-		chromedp.Navigate(searchURL),
-		chromedp.WaitReady("body"),
-		chromedp.Sleep(n.config.WaitTime),
-		chromedp.Title(&pageTitle),
-		chromedp.Location(&pageURL),
-	)
+	var pageTitle string
+	_ = br.Eval(ctx, "document.title", &pageTitle)
 
+	pageURL, err := br.Location(ctx)
 	if err != nil {
-		n.logVeryVerbose("chromedp.Run failed with error: %v", err)
+		n.logVeryVerbose("location failed", "error", err)
 		return "", fmt.Errorf("failed to navigate: %w", err)
 	}
-	n.logVeryVerbose("chromedp.Run completed successfully")
-
 	debugInfo.PageTitle = pageTitle
 	debugInfo.PageURL = pageURL
-	n.logVerbose("✓ Page loaded: %s", pageTitle)
-	n.logVeryVerbose("Current URL: %s", pageURL)
+	n.logVerbose("page loaded", "page_title", pageTitle)
+	n.logVeryVerbose("current url", "page_url", pageURL)
 
 	// Check for CAPTCHA/bot detection
 	if strings.Contains(pageURL, "/sorry/") {
 		debugInfo.CaptchaDetected = true
+		n.logBasic("captcha detected", "captcha", true, "page_url", pageURL)
 		return "", fmt.Errorf("Google blocked automated access (CAPTCHA/bot detection)\n\nThis happens with share.google links that redirect to search pages.\nPlease use the direct Maps URL instead:\n\n1. Open the share.google link in your browser\n2. Copy the final google.com/maps/place/ URL from the address bar\n3. Use that URL with this tool\n\nExample: gmaps2vcard \"https://www.google.com/maps/place/...\"")
 	}
 
-	// Strategy 1: Click on the address link
-	// COMMENTED OUT: This strategy triggers bot detection and rarely works
-	// Clicking elements on search pages often leads to CAPTCHA or stays on same page
-	// Keeping code for reference but disabled for production use
-	/*
-		n.logVerbose("→ Strategy 1: Clicking on address link to navigate to maps/place...")
-		attempt1 := SearchAttempt{Method: "click-address-link"}
-
-		var locationAfterClick string
-		err = chromedp.Run(ctx,
-			chromedp.Click(`a[data-url*="/maps/place/"]`, chromedp.ByQuery),
-			chromedp.Sleep(3*time.Second),
-			chromedp.Location(&locationAfterClick),
-		)
-
-		attempt1.Value = locationAfterClick
-		if err == nil && strings.Contains(locationAfterClick, "/maps/place/") {
-			if strings.Contains(locationAfterClick, "/sorry/") {
-				attempt1.Error = fmt.Errorf("clicked but redirected to CAPTCHA page")
-				debugInfo.SearchAttempts = append(debugInfo.SearchAttempts, attempt1)
-				debugInfo.CaptchaDetected = true
-				n.logVeryVerbose("✗ Click led to CAPTCHA page: %s", locationAfterClick)
-			} else {
-				attempt1.Success = true
-				debugInfo.SearchAttempts = append(debugInfo.SearchAttempts, attempt1)
-				n.logVerbose("✓ Successfully clicked and navigated to: %s", locationAfterClick)
-				return n.makeAbsoluteURL(locationAfterClick), nil
-			}
-		} else {
-			attempt1.Error = err
-			debugInfo.SearchAttempts = append(debugInfo.SearchAttempts, attempt1)
-			n.logVeryVerbose("✗ Click strategy failed: %v (URL: %s)", err, locationAfterClick)
+	// Clicking elements on search pages triggers bot detection far more
+	// often than reading an attribute does, so every strategy below reads
+	// rather than navigates.
+	for i, strat := range n.strategies {
+		n.logVerbose("trying strategy", "strategy", strat.Name(), "attempt", i+1)
+
+		value, strategyErr := n.runStrategy(ctx, strat, br)
+		attempt := SearchAttempt{Method: strat.Name()}
+
+		if strategyErr == nil && value != "" {
+			attempt.Success = true
+			attempt.Value = value
+			debugInfo.SearchAttempts = append(debugInfo.SearchAttempts, attempt)
+			n.logVerbose("strategy extracted value", "strategy", strat.Name(), "value", value)
+			n.saveCookieJar(ctx, br, searchURL)
+			return n.makeAbsoluteURL(value), nil
 		}
-	*/
 
-	// Strategy 1: Extract href from address link (most reliable, avoids bot detection)
-	n.logVerbose("→ Strategy 1: Extracting href from address link...")
-	attempt1 := SearchAttempt{Method: "extract-href-address"}
+		attempt.Error = strategyErr
+		debugInfo.SearchAttempts = append(debugInfo.SearchAttempts, attempt)
+		n.logVeryVerbose("strategy failed", "strategy", strat.Name(), "error", strategyErr)
+	}
 
-	var hrefFull string
-	err = chromedp.Run(ctx,
-		chromedp.AttributeValue(`div[data-attrid="kc:/location/location:address"] a[href*="/maps/place/"]`, "href", &hrefFull, nil, chromedp.ByQuery),
-	)
+	n.saveCookieJar(ctx, br, searchURL)
 
-	if err == nil && hrefFull != "" {
-		attempt1.Success = true
-		attempt1.Value = hrefFull
-		debugInfo.SearchAttempts = append(debugInfo.SearchAttempts, attempt1)
-		n.logVerbose("✓ Extracted href from address: %s", hrefFull)
-		return n.makeAbsoluteURL(hrefFull), nil
-	}
-	attempt1.Error = err
-	debugInfo.SearchAttempts = append(debugInfo.SearchAttempts, attempt1)
-	n.logVeryVerbose("✗ href from address extraction failed: %v", err)
+	// All strategies failed
+	return "", fmt.Errorf("failed to extract maps/place link after trying %d strategies\nGoogle may have changed their page structure", len(debugInfo.SearchAttempts))
+}
 
-	// Strategy 2: Extract data-url attribute (fallback, gives minimal URL)
-	n.logVerbose("→ Strategy 2: Extracting data-url attribute...")
-	attempt2 := SearchAttempt{Method: "extract-data-url"}
+// saveCookieJar copies br's current cookies for searchURL back into
+// n.config.CookieJar, so a CAPTCHA solved (or consent accepted) during this
+// run is already in place the next time this jar is used. Errors are logged
+// rather than returned: a failed save shouldn't turn an otherwise successful
+// extraction into a failure.
+func (n *Normalizer) saveCookieJar(ctx context.Context, br Browser, searchURL string) {
+	if n.config.CookieJar == nil {
+		return
+	}
+	u, err := url.Parse(searchURL)
+	if err != nil {
+		return
+	}
+	cookies, err := br.Cookies(ctx, searchURL)
+	if err != nil {
+		n.logVeryVerbose("failed to read cookies to save", "error", err)
+		return
+	}
+	n.config.CookieJar.SetCookies(u, cookies)
+}
 
-	var dataURL string
-	err = chromedp.Run(ctx,
-		chromedp.AttributeValue(`a[data-url*="/maps/place/"]`, "data-url", &dataURL, nil, chromedp.ByQuery),
-	)
+// runStrategy runs strat against page, retrying up to n.config.StrategyRetries
+// times with exponential backoff (mirroring transport's proxy retry loop)
+// before giving up on it so extractFromSearchPage can move to the next one.
+func (n *Normalizer) runStrategy(ctx context.Context, strat Strategy, page Page) (string, error) {
+	backoff := n.config.StrategyBackoff
+	var lastErr error
+
+	for attempt := 0; attempt <= n.config.StrategyRetries; attempt++ {
+		value, err := strat.Extract(ctx, page)
+		if err == nil && value != "" {
+			return value, nil
+		}
+		lastErr = err
 
-	if err == nil && dataURL != "" {
-		attempt2.Success = true
-		attempt2.Value = dataURL
-		debugInfo.SearchAttempts = append(debugInfo.SearchAttempts, attempt2)
-		n.logVerbose("✓ Extracted data-url: %s", dataURL)
-		return n.makeAbsoluteURL(dataURL), nil
+		if attempt < n.config.StrategyRetries {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
 	}
-	attempt2.Error = err
-	debugInfo.SearchAttempts = append(debugInfo.SearchAttempts, attempt2)
-	n.logVeryVerbose("✗ data-url extraction failed: %v", err)
 
-	// All strategies failed
-	return "", fmt.Errorf("failed to extract maps/place link after trying %d strategies\nGoogle may have changed their page structure", len(debugInfo.SearchAttempts))
+	return "", lastErr
 }
 
 // makeAbsoluteURL converts relative URLs to absolute
@@ -366,7 +446,8 @@ func (n *Normalizer) makeAbsoluteURL(urlStr string) string {
 	return urlStr
 }
 
-// PrintDebugInfo prints detailed debugging information
+// PrintDebugInfo logs result.DebugInfo through n.config.Logger at Info level:
+// one record summarizing the run, followed by one per search attempt.
 func (n *Normalizer) PrintDebugInfo(result *Result) {
 	if result.DebugInfo == nil {
 		return
@@ -374,68 +455,45 @@ func (n *Normalizer) PrintDebugInfo(result *Result) {
 
 	info := result.DebugInfo
 
-	fmt.Println("\n=== URL Normalization Debug Info ===")
-	fmt.Printf("Input URL: %s\n", info.InputURL)
-	fmt.Printf("Redirected URL: %s\n", info.RedirectedURL)
-	fmt.Printf("Detected Type: %s\n", info.DetectedType)
-	fmt.Printf("Redirect Time: %v\n", info.RedirectTime)
-	fmt.Printf("Extraction Time: %v\n", info.ExtractionTime)
-	fmt.Printf("Total Time: %v\n", info.TotalTime)
-
-	if info.PageTitle != "" {
-		fmt.Printf("Page Title: %s\n", info.PageTitle)
-	}
-	if info.PageURL != "" {
-		fmt.Printf("Page URL: %s\n", info.PageURL)
-	}
-	if info.CaptchaDetected {
-		fmt.Printf("CAPTCHA Detected: YES\n")
-	}
-
-	if len(info.SearchAttempts) > 0 {
-		fmt.Println("\nSearch Extraction Attempts:")
-		for i, attempt := range info.SearchAttempts {
-			status := "✗"
-			if attempt.Success {
-				status = "✓"
-			}
-			fmt.Printf("  %s [%d] %s\n", status, i+1, attempt.Method)
-			if attempt.Error != nil {
-				fmt.Printf("      Error: %v\n", attempt.Error)
-			}
-			if attempt.Value != "" {
-				fmt.Printf("      Value: %s\n", attempt.Value)
-			}
-		}
-	}
+	n.config.Logger.Info("normalization debug info",
+		"input_url", info.InputURL,
+		"redirected_url", info.RedirectedURL,
+		"detected_type", info.DetectedType,
+		"redirect_time", info.RedirectTime,
+		"extraction_time", info.ExtractionTime,
+		"duration_ms", info.TotalTime.Milliseconds(),
+		"page_title", info.PageTitle,
+		"page_url", info.PageURL,
+		"captcha", info.CaptchaDetected,
+		"success", result.Success,
+		"normalized_url", result.NormalizedURL,
+		"url_type", result.URLType,
+		"error", result.Error,
+	)
 
-	if result.Success {
-		fmt.Printf("\n✓ Result: %s\n", result.NormalizedURL)
-		fmt.Printf("URL Type: %s\n", result.URLType)
-	} else {
-		fmt.Printf("\n✗ Failed to normalize URL\n")
-		if result.Error != nil {
-			fmt.Printf("Error: %v\n", result.Error)
-		}
+	for i, attempt := range info.SearchAttempts {
+		n.config.Logger.Info("search attempt",
+			"index", i,
+			"strategy", attempt.Method,
+			"success", attempt.Success,
+			"value", attempt.Value,
+			"error", attempt.Error,
+		)
 	}
-	fmt.Println("=====================================")
 }
 
-// Logging helpers
-func (n *Normalizer) logBasic(format string, args ...interface{}) {
-	if n.config.DebugLevel >= DebugBasic {
-		log.Printf("[URLNormalizer] "+format, args...)
-	}
+// Logging helpers route through n.config.Logger at the three levels
+// DebugLevel gates: Info for logBasic, Debug for logVerbose, and the custom
+// LevelVeryVerbose for logVeryVerbose. slog checks the handler's configured
+// level before formatting, so these are cheap no-ops when disabled.
+func (n *Normalizer) logBasic(msg string, args ...any) {
+	n.config.Logger.Info(msg, args...)
 }
 
-func (n *Normalizer) logVerbose(format string, args ...interface{}) {
-	if n.config.DebugLevel >= DebugVerbose {
-		log.Printf("[URLNormalizer] "+format, args...)
-	}
+func (n *Normalizer) logVerbose(msg string, args ...any) {
+	n.config.Logger.Debug(msg, args...)
 }
 
-func (n *Normalizer) logVeryVerbose(format string, args ...interface{}) {
-	if n.config.DebugLevel >= DebugVeryVerbose {
-		log.Printf("[URLNormalizer] "+format, args...)
-	}
+func (n *Normalizer) logVeryVerbose(msg string, args ...any) {
+	n.config.Logger.Log(context.Background(), LevelVeryVerbose, msg, args...)
 }
@@ -0,0 +1,114 @@
+package urlnormalizer
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// Page is the subset of browser operations a Strategy needs against an
+// already-loaded search results page. Browser embeds Page, so any Browser
+// backend doubles as the Page a Strategy runs against; callers that want to
+// unit test a Strategy in isolation can implement just this much instead.
+type Page interface {
+	// Attribute returns the named attribute of the first element matching
+	// selector. ok is false if no element matches or the attribute is unset.
+	Attribute(ctx context.Context, selector, attr string) (value string, ok bool, err error)
+	// Eval runs js against the page and decodes its JSON-serializable
+	// result into out.
+	Eval(ctx context.Context, js string, out interface{}) error
+}
+
+// Strategy is one way to pull the maps/place link out of a loaded Google
+// search results page. Normalizer tries its registered strategies in order
+// until one succeeds, so that a single rotted selector doesn't take down
+// extraction entirely.
+type Strategy interface {
+	// Name identifies the strategy in SearchAttempt.Method and debug logs.
+	Name() string
+	// Extract attempts to find the maps/place URL on page. A nil error with
+	// an empty string means "not found, try the next strategy" - only
+	// return a non-nil error for a failure worth surfacing in
+	// SearchAttempt.Error (e.g. a JS eval or context-deadline failure).
+	Extract(ctx context.Context, page Page) (string, error)
+}
+
+// hrefFromAddressCard extracts the href of the knowledge panel's address
+// link, today's most reliable strategy since it reads an attribute rather
+// than clicking anything (clicking tends to trigger bot detection).
+type hrefFromAddressCard struct{}
+
+func (hrefFromAddressCard) Name() string { return "href-from-address-card" }
+
+func (hrefFromAddressCard) Extract(ctx context.Context, page Page) (string, error) {
+	value, ok, err := page.Attribute(ctx, `div[data-attrid="kc:/location/location:address"] a[href*="/maps/place/"]`, "href")
+	if err != nil || !ok {
+		return "", err
+	}
+	return value, nil
+}
+
+// dataURLAttr extracts the data-url attribute Google stamps onto the same
+// card's wrapping anchor. It's a narrower fallback: the value it yields is
+// often missing query parameters hrefFromAddressCard's link carries.
+type dataURLAttr struct{}
+
+func (dataURLAttr) Name() string { return "data-url-attr" }
+
+func (dataURLAttr) Extract(ctx context.Context, page Page) (string, error) {
+	value, ok, err := page.Attribute(ctx, `a[data-url*="/maps/place/"]`, "data-url")
+	if err != nil || !ok {
+		return "", err
+	}
+	return value, nil
+}
+
+// ldJSONLocalBusiness reads the page's embedded LD-JSON blocks for a
+// "@type": "LocalBusiness" entry and returns its "hasMap" or "url" field.
+// Structured data lives independently of the visible DOM, so it tends to
+// survive the layout changes that break selector-based strategies.
+type ldJSONLocalBusiness struct{}
+
+func (ldJSONLocalBusiness) Name() string { return "ld-json-local-business" }
+
+func (ldJSONLocalBusiness) Extract(ctx context.Context, page Page) (string, error) {
+	var blocks []string
+	if err := page.Eval(ctx, `Array.from(document.querySelectorAll('script[type="application/ld+json"]')).map(e => e.textContent)`, &blocks); err != nil {
+		return "", err
+	}
+
+	for _, block := range blocks {
+		var entry struct {
+			Type   string `json:"@type"`
+			URL    string `json:"url"`
+			HasMap string `json:"hasMap"`
+		}
+		if err := json.Unmarshal([]byte(block), &entry); err != nil {
+			continue // not the LocalBusiness block, or not an object at all
+		}
+		if entry.Type != "LocalBusiness" {
+			continue
+		}
+		if strings.Contains(entry.HasMap, "/maps/place/") {
+			return entry.HasMap, nil
+		}
+		if strings.Contains(entry.URL, "/maps/place/") {
+			return entry.URL, nil
+		}
+	}
+	return "", nil
+}
+
+// directionsAnchor extracts the href of the knowledge panel's "Directions"
+// link. Google redesigns the address card more often than this one.
+type directionsAnchor struct{}
+
+func (directionsAnchor) Name() string { return "directions-anchor" }
+
+func (directionsAnchor) Extract(ctx context.Context, page Page) (string, error) {
+	value, ok, err := page.Attribute(ctx, `a[data-value="Directions"][href*="/maps/place/"]`, "href")
+	if err != nil || !ok {
+		return "", err
+	}
+	return value, nil
+}
@@ -0,0 +1,80 @@
+package urlnormalizer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// stubPage is a Page backed by canned attribute/eval responses, for testing
+// Strategy implementations without a real browser.
+type stubPage struct {
+	attrs map[string]string // selector+"|"+attr -> value
+	eval  interface{}
+}
+
+func (p *stubPage) Attribute(ctx context.Context, selector, attr string) (string, bool, error) {
+	value, ok := p.attrs[selector+"|"+attr]
+	return value, ok, nil
+}
+
+func (p *stubPage) Eval(ctx context.Context, js string, out interface{}) error {
+	data, err := json.Marshal(p.eval)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func TestHrefFromAddressCard(t *testing.T) {
+	page := &stubPage{attrs: map[string]string{
+		`div[data-attrid="kc:/location/location:address"] a[href*="/maps/place/"]|href`: "https://www.google.com/maps/place/Example",
+	}}
+
+	got, err := hrefFromAddressCard{}.Extract(context.Background(), page)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if got != "https://www.google.com/maps/place/Example" {
+		t.Errorf("Extract = %q", got)
+	}
+}
+
+func TestHrefFromAddressCardNoMatch(t *testing.T) {
+	page := &stubPage{attrs: map[string]string{}}
+
+	got, err := hrefFromAddressCard{}.Extract(context.Background(), page)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if got != "" {
+		t.Errorf("Extract = %q, want empty (not found)", got)
+	}
+}
+
+func TestLdJSONLocalBusiness(t *testing.T) {
+	page := &stubPage{eval: []string{
+		`{"@type": "WebPage"}`,
+		`{"@type": "LocalBusiness", "url": "https://www.google.com/maps/place/Example"}`,
+	}}
+
+	got, err := ldJSONLocalBusiness{}.Extract(context.Background(), page)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if got != "https://www.google.com/maps/place/Example" {
+		t.Errorf("Extract = %q", got)
+	}
+}
+
+func TestLdJSONLocalBusinessNoMatch(t *testing.T) {
+	page := &stubPage{eval: []string{`{"@type": "WebPage"}`}}
+
+	got, err := ldJSONLocalBusiness{}.Extract(context.Background(), page)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if got != "" {
+		t.Errorf("Extract = %q, want empty (not found)", got)
+	}
+}
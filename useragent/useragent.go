@@ -0,0 +1,308 @@
+// Package useragent maintains a weighted pool of current browser versions so
+// requests and chromedp sessions present a plausible, up-to-date User-Agent
+// instead of a single frozen string that gets more fingerprintable (and more
+// likely to trip consent/interstitial pages) every month it goes unchanged.
+package useragent
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Browser identifies a UA family.
+type Browser string
+
+const (
+	Chrome  Browser = "chrome"
+	Firefox Browser = "firefox"
+)
+
+// version pairs a concrete version string with its global usage share.
+type version struct {
+	Version string
+	Global  float64
+}
+
+// defaultVersions seeds the pool before the first refresh, and is fallen
+// back to if a refresh ever fails.
+var defaultVersions = map[Browser][]version{
+	Chrome: {
+		{Version: "124.0.0.0", Global: 65.0},
+		{Version: "123.0.0.0", Global: 15.0},
+		{Version: "122.0.0.0", Global: 8.0},
+	},
+	Firefox: {
+		{Version: "125.0", Global: 7.0},
+		{Version: "124.0", Global: 5.0},
+	},
+}
+
+// caniuseURL is the fulldata JSON caniuse publishes with global usage share
+// per browser version.
+const caniuseURL = "https://raw.githubusercontent.com/Fyrd/caniuse/main/fulldata-json/data-2.0.json"
+
+// Pool holds a weighted pool of current browser versions, refreshed from a
+// remote usage-share feed on a TTL.
+type Pool struct {
+	sourceURL string
+	ttl       time.Duration
+	client    *http.Client
+
+	mu       sync.RWMutex
+	versions map[Browser][]version
+	expires  time.Time
+}
+
+// NewPool creates a Pool that refreshes from sourceURL at most once per ttl.
+func NewPool(sourceURL string, ttl time.Duration) *Pool {
+	return &Pool{
+		sourceURL: sourceURL,
+		ttl:       ttl,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		versions:  defaultVersions,
+	}
+}
+
+var defaultPool = NewPool(caniuseURL, 24*time.Hour)
+
+// Identity is a full, internally-consistent set of User-Agent and
+// Client Hints headers for one picked browser version.
+type Identity struct {
+	UserAgent string
+
+	// SecChUa and SecChUaPlatform are the matching Client Hints headers.
+	// Firefox doesn't send Client Hints at all, so both are empty when the
+	// picked browser is Firefox.
+	SecChUa         string
+	SecChUaPlatform string
+}
+
+// Apply sets req's User-Agent and (when present) Sec-Ch-Ua / Sec-Ch-Ua-Platform
+// headers to this Identity's values.
+func (id Identity) Apply(req *http.Request) {
+	req.Header.Set("User-Agent", id.UserAgent)
+	if id.SecChUa != "" {
+		req.Header.Set("Sec-Ch-Ua", id.SecChUa)
+		req.Header.Set("Sec-Ch-Ua-Platform", id.SecChUaPlatform)
+	}
+}
+
+// Random returns a UA string for a browser family chosen proportional to
+// current global usage share, using the package-level default pool.
+func Random() string {
+	return defaultPool.Random()
+}
+
+// RandomFor returns a UA string for a specific browser family, using the
+// package-level default pool.
+func RandomFor(browser Browser) string {
+	return defaultPool.RandomFor(browser)
+}
+
+// Pick returns a full Identity for a browser family chosen proportional to
+// current global usage share, using the package-level default pool.
+func Pick() Identity {
+	return defaultPool.Pick()
+}
+
+// PickFor returns a full Identity for a specific browser family, using the
+// package-level default pool.
+func PickFor(browser Browser) Identity {
+	return defaultPool.PickFor(browser)
+}
+
+// Random returns a UA string for a browser family chosen proportional to
+// current global usage share.
+func (p *Pool) Random() string {
+	return p.Pick().UserAgent
+}
+
+// RandomFor returns a UA string for a specific browser family.
+func (p *Pool) RandomFor(browser Browser) string {
+	return p.PickFor(browser).UserAgent
+}
+
+// Pick returns a full Identity for a browser family chosen proportional to
+// current global usage share.
+func (p *Pool) Pick() Identity {
+	p.refreshIfStale()
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	chromeShare := totalShare(p.versions[Chrome])
+	firefoxShare := totalShare(p.versions[Firefox])
+
+	browser := Firefox
+	if rand.Float64()*(chromeShare+firefoxShare) < chromeShare {
+		browser = Chrome
+	}
+	return identity(browser, pickWeighted(p.versions[browser]))
+}
+
+// PickFor returns a full Identity for a specific browser family.
+func (p *Pool) PickFor(browser Browser) Identity {
+	p.refreshIfStale()
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return identity(browser, pickWeighted(p.versions[browser]))
+}
+
+func totalShare(versions []version) float64 {
+	var total float64
+	for _, v := range versions {
+		total += v.Global
+	}
+	return total
+}
+
+// pickWeighted does weighted random sampling over versions by Global share.
+func pickWeighted(versions []version) string {
+	if len(versions) == 0 {
+		return ""
+	}
+
+	total := totalShare(versions)
+	if total <= 0 {
+		return versions[0].Version
+	}
+
+	r := rand.Float64() * total
+	for _, v := range versions {
+		r -= v.Global
+		if r <= 0 {
+			return v.Version
+		}
+	}
+	return versions[len(versions)-1].Version
+}
+
+func uaString(browser Browser, version string) string {
+	if browser == Firefox {
+		return fmt.Sprintf("Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:%s) Gecko/20100101 Firefox/%s", version, version)
+	}
+	return fmt.Sprintf("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%s Safari/537.36", version)
+}
+
+// identity builds the Identity for a browser family and picked version,
+// matching the platform baked into uaString.
+func identity(browser Browser, version string) Identity {
+	id := Identity{UserAgent: uaString(browser, version)}
+	if browser == Chrome {
+		major := version
+		if dot := strings.Index(version, "."); dot != -1 {
+			major = version[:dot]
+		}
+		id.SecChUa = fmt.Sprintf(`"Not_A Brand";v="8", "Chromium";v="%s", "Google Chrome";v="%s"`, major, major)
+		id.SecChUaPlatform = `"macOS"`
+	}
+	return id
+}
+
+// refreshIfStale re-fetches version shares once the TTL has expired.
+// Network or parse errors are logged and the existing (or default) pool is
+// kept, so a failed refresh never breaks callers.
+func (p *Pool) refreshIfStale() {
+	p.mu.RLock()
+	stale := time.Now().After(p.expires)
+	p.mu.RUnlock()
+	if !stale {
+		return
+	}
+
+	versions, err := p.fetch()
+	if err != nil {
+		log.Printf("[useragent] refresh failed, keeping existing pool: %v", err)
+		versions = nil
+	}
+
+	p.mu.Lock()
+	if versions != nil {
+		p.versions = versions
+	}
+	p.expires = time.Now().Add(p.ttl)
+	p.mu.Unlock()
+}
+
+// caniuseAgent is the subset of caniuse's per-browser fields we need.
+type caniuseAgent struct {
+	UsageGlobal map[string]float64 `json:"usage_global"`
+}
+
+type caniuseResponse struct {
+	Agents map[string]caniuseAgent `json:"agents"`
+}
+
+// maxVersionsPerBrowser bounds how many of the most-used versions we keep
+// per family, so Random doesn't spend its weight on long-tail versions.
+const maxVersionsPerBrowser = 8
+
+// fetch downloads and parses the caniuse fulldata JSON into a weighted
+// version pool per browser family.
+func (p *Pool) fetch() (map[Browser][]version, error) {
+	resp, err := p.client.Get(p.sourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", p.sourceURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var data caniuseResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode caniuse data: %w", err)
+	}
+
+	result := make(map[Browser][]version)
+	for _, browser := range []Browser{Chrome, Firefox} {
+		agent, ok := data.Agents[string(browser)]
+		if !ok {
+			continue
+		}
+		result[browser] = topVersions(agent.UsageGlobal, maxVersionsPerBrowser)
+	}
+
+	if len(result[Chrome]) == 0 && len(result[Firefox]) == 0 {
+		return nil, fmt.Errorf("no usable version data in caniuse response")
+	}
+
+	return result, nil
+}
+
+// topVersions picks the n numerically-highest version keys with positive
+// usage share.
+func topVersions(usageGlobal map[string]float64, n int) []version {
+	versions := make([]version, 0, len(usageGlobal))
+	for v, share := range usageGlobal {
+		if share <= 0 || strings.Contains(v, "-") {
+			continue // caniuse uses ranges like "70-71" for some entries; skip those
+		}
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			continue
+		}
+		versions = append(versions, version{Version: v, Global: share})
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		vi, _ := strconv.ParseFloat(versions[i].Version, 64)
+		vj, _ := strconv.ParseFloat(versions[j].Version, 64)
+		return vi > vj
+	})
+
+	if len(versions) > n {
+		versions = versions[:n]
+	}
+	return versions
+}
@@ -0,0 +1,78 @@
+package useragent
+
+import "testing"
+
+func TestPickWeighted(t *testing.T) {
+	versions := []version{
+		{Version: "124.0.0.0", Global: 65.0},
+		{Version: "123.0.0.0", Global: 0},
+		{Version: "122.0.0.0", Global: 0},
+	}
+
+	// With every other version at zero share, the weighted pick must always
+	// land on the one with positive share.
+	for i := 0; i < 20; i++ {
+		if got := pickWeighted(versions); got != "124.0.0.0" {
+			t.Fatalf("pickWeighted() = %q, want %q", got, "124.0.0.0")
+		}
+	}
+}
+
+func TestPickWeightedEmpty(t *testing.T) {
+	if got := pickWeighted(nil); got != "" {
+		t.Errorf("pickWeighted(nil) = %q, want \"\"", got)
+	}
+}
+
+func TestPickWeightedZeroTotal(t *testing.T) {
+	versions := []version{{Version: "124.0.0.0", Global: 0}, {Version: "123.0.0.0", Global: 0}}
+	if got := pickWeighted(versions); got != "124.0.0.0" {
+		t.Errorf("pickWeighted() with zero total = %q, want first version %q", got, "124.0.0.0")
+	}
+}
+
+func TestUAString(t *testing.T) {
+	cases := []struct {
+		browser Browser
+		version string
+		want    string
+	}{
+		{Chrome, "124.0.0.0", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"},
+		{Firefox, "125.0", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:125.0) Gecko/20100101 Firefox/125.0"},
+	}
+	for _, c := range cases {
+		if got := uaString(c.browser, c.version); got != c.want {
+			t.Errorf("uaString(%q, %q) = %q, want %q", c.browser, c.version, got, c.want)
+		}
+	}
+}
+
+func TestTopVersions(t *testing.T) {
+	usage := map[string]float64{
+		"124":   65.0,
+		"123":   15.0,
+		"122":   8.0,
+		"70-71": 5.0, // range key, must be skipped
+		"121":   0,   // zero share, must be skipped
+		"bad":   3.0, // non-numeric, must be skipped
+	}
+
+	got := topVersions(usage, 2)
+	want := []string{"124", "123"}
+	if len(got) != len(want) {
+		t.Fatalf("topVersions() = %v, want %d entries", got, len(want))
+	}
+	for i, v := range want {
+		if got[i].Version != v {
+			t.Errorf("topVersions()[%d] = %q, want %q", i, got[i].Version, v)
+		}
+	}
+}
+
+func TestTopVersionsFewerThanN(t *testing.T) {
+	usage := map[string]float64{"124": 65.0}
+	got := topVersions(usage, 5)
+	if len(got) != 1 || got[0].Version != "124" {
+		t.Errorf("topVersions() = %v, want [124]", got)
+	}
+}